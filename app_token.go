@@ -0,0 +1,43 @@
+package gokick
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// renewAppAccessToken fetches a new app access token, unless staleAccessToken has already been replaced.
+// Concurrent calls share a single GetAppAccessToken request.
+func (c *Client) renewAppAccessToken(ctx context.Context, staleAccessToken string) error {
+	return c.singleFlight(
+		ctx,
+		&c.appTokenRenewal,
+		func() bool { return c.options.AppAccessToken == staleAccessToken },
+		c.fetchAppAccessToken,
+	)
+}
+
+func (c *Client) fetchAppAccessToken(ctx context.Context) error {
+	token, err := c.GetAppAccessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get app access token: %w", err)
+	}
+
+	var expiresAt time.Time
+	if token.ExpiresIn > 0 {
+		expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	c.mu.Lock()
+	c.options.AppAccessToken = token.AccessToken
+	c.appAccessTokenExpiresAt = expiresAt
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *Client) canRenewAppToken() bool {
+	return c.options.AutoAppAccessToken &&
+		c.options.ClientID != "" &&
+		c.options.ClientSecret != ""
+}
@@ -0,0 +1,135 @@
+package gokick_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTransport counts the token requests sent to the OAuth server.
+type countingTransport struct {
+	tokenRequests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/oauth/token" {
+		t.tokenRequests.Add(1)
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func setupAppTokenClient(t *testing.T, options *gokick.ClientOptions) (*kicktest.Server, *gokick.Client, *countingTransport) {
+	t.Helper()
+
+	server := kicktest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.AddCategory(gokick.CategoryResponse{ID: 1, Name: "Just Chatting"})
+
+	transport := &countingTransport{}
+	options.HTTPClient = &http.Client{Transport: transport}
+
+	client, err := server.NewClient(options)
+	require.NoError(t, err)
+
+	return server, client, transport
+}
+
+func TestAutoAppAccessTokenError(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		_, client, transport := setupAppTokenClient(t, &gokick.ClientOptions{ClientID: "client-id", ClientSecret: "client-secret"})
+
+		_, err := client.GetCategory(context.Background(), 1)
+		require.EqualError(t, err, "Error 401: Unauthorized")
+		assert.Equal(t, int32(0), transport.tokenRequests.Load())
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		server, client, _ := setupAppTokenClient(t, &gokick.ClientOptions{
+			ClientID:           "client-id",
+			ClientSecret:       "wrong-secret",
+			AutoAppAccessToken: true,
+		})
+		server.SetClientCredentials("client-id", "client-secret")
+
+		_, err := client.GetCategory(context.Background(), 1)
+		require.ErrorContains(t, err, "failed to make request: failed to get app access token: ")
+	})
+}
+
+func TestAutoAppAccessTokenSuccess(t *testing.T) {
+	t.Run("fetched lazily and cached", func(t *testing.T) {
+		_, client, transport := setupAppTokenClient(t, &gokick.ClientOptions{
+			ClientID:           "client-id",
+			ClientSecret:       "client-secret",
+			AutoAppAccessToken: true,
+		})
+		assert.Equal(t, int32(0), transport.tokenRequests.Load())
+
+		for range 3 {
+			_, err := client.GetCategory(context.Background(), 1)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(1), transport.tokenRequests.Load())
+	})
+
+	t.Run("renewed on 401", func(t *testing.T) {
+		server, client, transport := setupAppTokenClient(t, &gokick.ClientOptions{
+			AppAccessToken:     "unknown-token",
+			ClientID:           "client-id",
+			ClientSecret:       "client-secret",
+			AutoAppAccessToken: true,
+		})
+
+		_, err := client.GetCategory(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), transport.tokenRequests.Load())
+
+		token := server.IssueAppToken()
+		client.SetAppAccessToken(token.AccessToken)
+		server.ExpireToken(token.AccessToken)
+
+		_, err = client.GetCategory(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), transport.tokenRequests.Load())
+	})
+
+	t.Run("renewed before expiry", func(t *testing.T) {
+		server, client, transport := setupAppTokenClient(t, &gokick.ClientOptions{
+			ClientID:           "client-id",
+			ClientSecret:       "client-secret",
+			AutoAppAccessToken: true,
+			TokenRefreshSkew:   time.Minute,
+		})
+		server.SetTokenLifetime(30 * time.Second)
+
+		_, err := client.GetCategory(context.Background(), 1)
+		require.NoError(t, err)
+
+		_, err = client.GetCategory(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), transport.tokenRequests.Load())
+	})
+
+	t.Run("user token takes precedence", func(t *testing.T) {
+		server, client, transport := setupAppTokenClient(t, &gokick.ClientOptions{
+			ClientID:           "client-id",
+			ClientSecret:       "client-secret",
+			AutoAppAccessToken: true,
+		})
+		client.SetUserAccessToken(server.IssueUserToken(721956).AccessToken)
+
+		_, err := client.GetCategory(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), transport.tokenRequests.Load())
+	})
+}
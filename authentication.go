@@ -59,6 +59,7 @@ func (c *Client) GetToken(ctx context.Context, redirectURI, code, codeVerifier s
 	response, err := makeAuthRequest[TokenResponse](
 		ctx,
 		c,
+		Operation{Name: "GetToken"},
 		http.MethodPost,
 		"/oauth/token",
 		http.StatusOK,
@@ -88,6 +89,7 @@ func (c *Client) GetAppAccessToken(ctx context.Context) (AppTokenResponse, error
 	response, err := makeAuthRequest[AppTokenResponse](
 		ctx,
 		c,
+		Operation{Name: "GetAppAccessToken"},
 		http.MethodPost,
 		"/oauth/token",
 		http.StatusOK,
@@ -122,6 +124,7 @@ func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (TokenRe
 	response, err := makeAuthRequest[TokenResponse](
 		ctx,
 		c,
+		Operation{Name: "RefreshToken"},
 		http.MethodPost,
 		"/oauth/token",
 		http.StatusOK,
@@ -142,6 +145,7 @@ func (c *Client) RevokeToken(ctx context.Context, tokenType TokenType, token str
 	_, err := makeAuthRequest[TokenResponse](
 		ctx,
 		c,
+		Operation{Name: "RevokeToken"},
 		http.MethodPost,
 		"/oauth/revoke",
 		http.StatusOK,
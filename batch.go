@@ -0,0 +1,159 @@
+package gokick
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxBatchSize is the maximum number of IDs, or slugs, sent per request, under the limit of KICK.
+	maxBatchSize            = 50
+	defaultBatchConcurrency = 4
+)
+
+// BatchResult is the result of a bulk lookup.
+type BatchResult[K comparable, T any] struct {
+	// Found are the results, in the order of the requested keys. Duplicated keys have a single result.
+	Found []T
+	// NotFound are the requested keys without result, in the order of the request.
+	NotFound []K
+}
+
+// GetChannelsByIDs gets the channels of the broadcasters ids, whatever their number: the IDs are split into chunks
+// sent with at most ClientOptions.BatchConcurrency concurrent requests.
+func (c *Client) GetChannelsByIDs(ctx context.Context, ids []int) (BatchResult[int, ChannelResponse], error) {
+	return getBatch(
+		ctx,
+		c.options.BatchConcurrency,
+		ids,
+		func(ctx context.Context, chunk []int) ([]ChannelResponse, error) {
+			response, err := c.GetChannels(ctx, NewChannelListFilter().SetBroadcasterUserIDs(chunk))
+			return response.Result, err
+		},
+		func(channel ChannelResponse) int { return channel.BroadcasterUserID },
+		nil,
+	)
+}
+
+// GetChannelsBySlugs gets the channels of slugs, whatever their number, as GetChannelsByIDs does. Slugs are
+// compared case-insensitively.
+func (c *Client) GetChannelsBySlugs(ctx context.Context, slugs []string) (BatchResult[string, ChannelResponse], error) {
+	return getBatch(
+		ctx,
+		c.options.BatchConcurrency,
+		slugs,
+		func(ctx context.Context, chunk []string) ([]ChannelResponse, error) {
+			response, err := c.GetChannels(ctx, NewChannelListFilter().SetSlug(chunk))
+			return response.Result, err
+		},
+		func(channel ChannelResponse) string { return strings.ToLower(channel.Slug) },
+		strings.ToLower,
+	)
+}
+
+// GetUsersByIDs gets the users ids, whatever their number, as GetChannelsByIDs does.
+func (c *Client) GetUsersByIDs(ctx context.Context, ids []int) (BatchResult[int, UserResponse], error) {
+	return getBatch(
+		ctx,
+		c.options.BatchConcurrency,
+		ids,
+		func(ctx context.Context, chunk []int) ([]UserResponse, error) {
+			response, err := c.GetUsers(ctx, NewUserListFilter().SetIDs(chunk))
+			return response.Result, err
+		},
+		func(user UserResponse) int { return user.UserID },
+		nil,
+	)
+}
+
+// getBatch fetches keys by chunks of maxBatchSize, with at most concurrency concurrent calls to fetch, and matches
+// the results to the keys with keyOf. normalize, when not nil, is applied to the keys before matching them.
+// The first error cancels the calls in flight and is returned.
+func getBatch[K comparable, T any](
+	ctx context.Context,
+	concurrency int,
+	keys []K,
+	fetch func(ctx context.Context, chunk []K) ([]T, error),
+	keyOf func(T) K,
+	normalize func(K) K,
+) (BatchResult[K, T], error) {
+	if normalize == nil {
+		normalize = func(key K) K { return key }
+	}
+
+	seen := make(map[K]bool, len(keys))
+	unique := make([]K, 0, len(keys))
+	for _, key := range keys {
+		if !seen[normalize(key)] {
+			seen[normalize(key)] = true
+			unique = append(unique, key)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg          sync.WaitGroup
+		errOnce     sync.Once
+		firstErr    error
+		interrupted error
+		chunks      = make([][]T, (len(unique)+maxBatchSize-1)/maxBatchSize)
+		slots       = make(chan struct{}, max(concurrency, 1))
+	)
+
+	for i := range chunks {
+		chunk := unique[i*maxBatchSize : min((i+1)*maxBatchSize, len(unique))]
+
+		slots <- struct{}{}
+		if ctx.Err() != nil {
+			// The remaining chunks are not fetched.
+			interrupted = ctx.Err()
+			break
+		}
+
+		wg.Go(func() {
+			defer func() { <-slots }()
+
+			found, err := fetch(ctx, chunk)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			chunks[i] = found
+		})
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		// The parent context was canceled before all the chunks were fetched. Once they all are, the result is
+		// complete and returned whatever the context.
+		firstErr = interrupted
+	}
+
+	if firstErr != nil {
+		return BatchResult[K, T]{}, firstErr
+	}
+
+	byKey := make(map[K]T, len(unique))
+	for _, results := range chunks {
+		for _, result := range results {
+			byKey[keyOf(result)] = result
+		}
+	}
+
+	var batch BatchResult[K, T]
+	for _, key := range unique {
+		if result, ok := byKey[normalize(key)]; ok {
+			batch.Found = append(batch.Found, result)
+		} else {
+			batch.NotFound = append(batch.NotFound, key)
+		}
+	}
+
+	return batch, nil
+}
@@ -0,0 +1,207 @@
+package gokick_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupBatchClient serves the channels and users whose ID (or slug "channel-ID") is even, and records the number of
+// IDs per request and the maximum number of concurrent requests.
+func setupBatchClient(t *testing.T, options *gokick.ClientOptions) (*gokick.Client, *[]int, *atomic.Int32) {
+	t.Helper()
+
+	var (
+		mu            sync.Mutex
+		requestSizes  []int
+		inFlight      atomic.Int32
+		maxConcurrent atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxConcurrent.Load()
+			if current <= observed || maxConcurrent.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		query := r.URL.Query()
+		keys := append(append(query["broadcaster_user_id"], query["id"]...), query["slug"]...)
+
+		mu.Lock()
+		requestSizes = append(requestSizes, len(keys))
+		mu.Unlock()
+
+		results := []map[string]interface{}{}
+		for _, key := range keys {
+			var id int
+			_, err := fmt.Sscanf(strings.ToLower(key), "channel-%d", &id)
+			if err != nil {
+				id, _ = strconv.Atoi(key)
+			}
+
+			if id%2 == 0 {
+				results = append(results, map[string]interface{}{
+					"broadcaster_user_id": id,
+					"user_id":             id,
+					"slug":                fmt.Sprintf("channel-%d", id),
+				})
+			}
+		}
+
+		body, _ := json.Marshal(map[string]interface{}{"message": "success", "data": results})
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	options.UserAccessToken = "access-token"
+	options.APIBaseURL = server.URL
+
+	client, err := gokick.NewClient(options)
+	require.NoError(t, err)
+
+	return client, &requestSizes, &maxConcurrent
+}
+
+func TestGetChannelsByIDsError(t *testing.T) {
+	t.Run("request error", func(t *testing.T) {
+		var requests atomic.Int32
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"internal server error", "data":null}`)
+		})
+
+		ids := make([]int, 1000)
+		for i := range ids {
+			ids[i] = i
+		}
+
+		_, err := kickClient.GetChannelsByIDs(context.Background(), ids)
+		require.EqualError(t, err, "Error 500: internal server error")
+		assert.Less(t, requests.Load(), int32(20), "the remaining chunks are not fetched")
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		kickClient, requestSizes, _ := setupBatchClient(t, &gokick.ClientOptions{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := kickClient.GetChannelsByIDs(ctx, []int{1, 2})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, *requestSizes)
+	})
+}
+
+func TestGetChannelsByIDsSuccess(t *testing.T) {
+	t.Run("chunks", func(t *testing.T) {
+		kickClient, requestSizes, maxConcurrent := setupBatchClient(t, &gokick.ClientOptions{BatchConcurrency: 2})
+
+		ids := make([]int, 0, 230)
+		for i := 230; i > 0; i-- {
+			ids = append(ids, i)
+		}
+
+		result, err := kickClient.GetChannelsByIDs(context.Background(), ids)
+		require.NoError(t, err)
+
+		require.Len(t, result.Found, 115)
+		assert.Equal(t, 230, result.Found[0].BroadcasterUserID)
+		assert.Equal(t, 228, result.Found[1].BroadcasterUserID)
+		assert.Equal(t, 2, result.Found[114].BroadcasterUserID)
+		require.Len(t, result.NotFound, 115)
+		assert.Equal(t, []int{229, 227}, result.NotFound[:2])
+
+		assert.ElementsMatch(t, []int{50, 50, 50, 50, 30}, *requestSizes)
+		assert.Equal(t, int32(2), maxConcurrent.Load())
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		kickClient, requestSizes, _ := setupBatchClient(t, &gokick.ClientOptions{})
+
+		result, err := kickClient.GetChannelsByIDs(context.Background(), []int{4, 3, 4, 3, 2})
+		require.NoError(t, err)
+
+		require.Len(t, result.Found, 2)
+		assert.Equal(t, 4, result.Found[0].BroadcasterUserID)
+		assert.Equal(t, 2, result.Found[1].BroadcasterUserID)
+		assert.Equal(t, []int{3}, result.NotFound)
+		assert.Equal(t, []int{3}, *requestSizes)
+	})
+
+	t.Run("context canceled after the last chunk", func(t *testing.T) {
+		kickClient, requestSizes, _ := setupBatchClient(t, &gokick.ClientOptions{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		kickClient.OnResponse(func(_ context.Context, _ gokick.ResponseMetadata) {
+			cancel()
+		})
+
+		result, err := kickClient.GetChannelsByIDs(ctx, []int{1, 2})
+		require.NoError(t, err)
+		require.Len(t, result.Found, 1)
+		assert.Equal(t, []int{1}, result.NotFound)
+		assert.Equal(t, []int{2}, *requestSizes)
+	})
+
+	t.Run("no IDs", func(t *testing.T) {
+		kickClient, requestSizes, _ := setupBatchClient(t, &gokick.ClientOptions{})
+
+		result, err := kickClient.GetChannelsByIDs(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Empty(t, result.Found)
+		assert.Empty(t, result.NotFound)
+		assert.Empty(t, *requestSizes)
+	})
+}
+
+func TestGetChannelsBySlugsSuccess(t *testing.T) {
+	kickClient, requestSizes, _ := setupBatchClient(t, &gokick.ClientOptions{})
+
+	slugs := make([]string, 0, 60)
+	for i := range 60 {
+		slugs = append(slugs, fmt.Sprintf("channel-%d", i))
+	}
+	slugs[10] = "Channel-10"
+
+	result, err := kickClient.GetChannelsBySlugs(context.Background(), slugs)
+	require.NoError(t, err)
+
+	require.Len(t, result.Found, 30)
+	assert.Equal(t, "channel-0", result.Found[0].Slug)
+	assert.Equal(t, "channel-10", result.Found[5].Slug)
+	require.Len(t, result.NotFound, 30)
+	assert.Equal(t, "channel-1", result.NotFound[0])
+	assert.ElementsMatch(t, []int{50, 10}, *requestSizes)
+}
+
+func TestGetUsersByIDsSuccess(t *testing.T) {
+	kickClient, requestSizes, _ := setupBatchClient(t, &gokick.ClientOptions{})
+
+	result, err := kickClient.GetUsersByIDs(context.Background(), []int{1, 2, 3, 4})
+	require.NoError(t, err)
+
+	require.Len(t, result.Found, 2)
+	assert.Equal(t, 2, result.Found[0].UserID)
+	assert.Equal(t, 4, result.Found[1].UserID)
+	assert.Equal(t, []int{1, 3}, result.NotFound)
+	assert.Equal(t, []int{4}, *requestSizes)
+}
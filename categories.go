@@ -3,8 +3,10 @@ package gokick
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 type (
@@ -36,6 +38,16 @@ func (f CategoryListFilter) SetPage(page int) CategoryListFilter {
 	return f
 }
 
+// withPage returns a copy of the filter set to page, leaving f untouched.
+func (f CategoryListFilter) withPage(page int) CategoryListFilter {
+	filter := NewCategoryListFilter()
+	for key, values := range f.queryParams {
+		filter.queryParams[key] = values
+	}
+
+	return filter.SetPage(page)
+}
+
 func (f CategoryListFilter) ToQueryString() string {
 	if len(f.queryParams) == 0 {
 		return ""
@@ -48,6 +60,7 @@ func (c *Client) GetCategories(ctx context.Context, filter CategoryListFilter) (
 	response, err := makeRequest[[]CategoryResponse](
 		ctx,
 		c,
+		Operation{Name: "GetCategories"},
 		http.MethodGet,
 		fmt.Sprintf("/public/v1/categories%s", filter.ToQueryString()),
 		http.StatusOK,
@@ -60,10 +73,47 @@ func (c *Client) GetCategories(ctx context.Context, filter CategoryListFilter) (
 	return CategoriesResponseWrapper(response), nil
 }
 
+// Categories iterates over the categories matching filter, fetching the pages lazily, from the page of filter (the
+// first one by default) until an empty page. The iteration stops after yielding an error, e.g. when ctx is canceled.
+func (c *Client) Categories(ctx context.Context, filter CategoryListFilter) iter.Seq2[CategoryResponse, error] {
+	return func(yield func(CategoryResponse, error) bool) {
+		page := 1
+		parsed, err := strconv.Atoi(filter.queryParams.Get("page"))
+		if err == nil {
+			page = parsed
+		}
+
+		for ; ; page++ {
+			err := ctx.Err()
+			if err != nil {
+				yield(CategoryResponse{}, err)
+				return
+			}
+
+			response, err := c.GetCategories(ctx, filter.withPage(page))
+			if err != nil {
+				yield(CategoryResponse{}, err)
+				return
+			}
+
+			if len(response.Result) == 0 {
+				return
+			}
+
+			for _, category := range response.Result {
+				if !yield(category, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
 func (c *Client) GetCategory(ctx context.Context, categoryID int) (CategoryResponseWrapper, error) {
 	response, err := makeRequest[CategoryResponse](
 		ctx,
 		c,
+		Operation{Name: "GetCategory"},
 		http.MethodGet,
 		fmt.Sprintf("/public/v1/categories/%d", categoryID),
 		http.StatusOK,
@@ -227,3 +227,114 @@ func TestGetCategorySuccess(t *testing.T) {
 	assert.Equal(t, "Hubert", categoryResponse.Result.Name)
 	assert.Equal(t, "Bonisseur de La Bath", categoryResponse.Result.Thumbnail)
 }
+
+func setupCategoriesPagesClient(t *testing.T, requestedPages *[]string) *gokick.Client {
+	t.Helper()
+
+	return setupMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		*requestedPages = append(*requestedPages, page)
+
+		switch page {
+		case "1":
+			fmt.Fprint(w, `{"message":"success", "data":[{"id":1, "name":"Just Chatting"}, {"id":2, "name":"Fortnite"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"message":"success", "data":[{"id":3, "name":"Minecraft"}]}`)
+		case "3":
+			fmt.Fprint(w, `{"message":"success", "data":[]}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"internal server error", "data":null}`)
+		}
+	})
+}
+
+func TestCategoriesError(t *testing.T) {
+	t.Run("request error", func(t *testing.T) {
+		var requestedPages []string
+		kickClient := setupCategoriesPagesClient(t, &requestedPages)
+
+		var ids []int
+		var iterationErr error
+		for category, err := range kickClient.Categories(context.Background(), gokick.NewCategoryListFilter().SetPage(4)) {
+			if err != nil {
+				iterationErr = err
+				continue
+			}
+			ids = append(ids, category.ID)
+		}
+
+		require.EqualError(t, iterationErr, "Error 500: internal server error")
+		assert.Empty(t, ids)
+		assert.Equal(t, []string{"4"}, requestedPages)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		var requestedPages []string
+		kickClient := setupCategoriesPagesClient(t, &requestedPages)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var ids []int
+		var iterationErr error
+		for category, err := range kickClient.Categories(ctx, gokick.NewCategoryListFilter()) {
+			if err != nil {
+				iterationErr = err
+				continue
+			}
+			ids = append(ids, category.ID)
+			cancel()
+		}
+
+		require.ErrorIs(t, iterationErr, context.Canceled)
+		assert.Equal(t, []int{1, 2}, ids)
+		assert.Equal(t, []string{"1"}, requestedPages)
+	})
+}
+
+func TestCategoriesSuccess(t *testing.T) {
+	t.Run("every page", func(t *testing.T) {
+		var requestedPages []string
+		kickClient := setupCategoriesPagesClient(t, &requestedPages)
+
+		filter := gokick.NewCategoryListFilter().SetQuery("a")
+
+		var names []string
+		for category, err := range kickClient.Categories(context.Background(), filter) {
+			require.NoError(t, err)
+			names = append(names, category.Name)
+		}
+
+		assert.Equal(t, []string{"Just Chatting", "Fortnite", "Minecraft"}, names)
+		assert.Equal(t, []string{"1", "2", "3"}, requestedPages)
+		assert.Equal(t, "?q=a", filter.ToQueryString(), "the filter is not modified")
+	})
+
+	t.Run("from page", func(t *testing.T) {
+		var requestedPages []string
+		kickClient := setupCategoriesPagesClient(t, &requestedPages)
+
+		var ids []int
+		for category, err := range kickClient.Categories(context.Background(), gokick.NewCategoryListFilter().SetPage(2)) {
+			require.NoError(t, err)
+			ids = append(ids, category.ID)
+		}
+
+		assert.Equal(t, []int{3}, ids)
+		assert.Equal(t, []string{"2", "3"}, requestedPages)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var requestedPages []string
+		kickClient := setupCategoriesPagesClient(t, &requestedPages)
+
+		for category, err := range kickClient.Categories(context.Background(), gokick.NewCategoryListFilter()) {
+			require.NoError(t, err)
+			assert.Equal(t, 1, category.ID)
+			break
+		}
+
+		assert.Equal(t, []string{"1"}, requestedPages, "pages are fetched lazily")
+	})
+}
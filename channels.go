@@ -71,6 +71,7 @@ func (c *Client) GetChannels(ctx context.Context, filter ChannelListFilter) (Cha
 	response, err := makeRequest[[]ChannelResponse](
 		ctx,
 		c,
+		Operation{Name: "GetChannels", Scopes: []Scope{ScopeChannelRead}},
 		http.MethodGet,
 		fmt.Sprintf("/public/v1/channels%s", filter.ToQueryString()),
 		http.StatusOK,
@@ -96,6 +97,7 @@ func (c *Client) UpdateStreamTitle(ctx context.Context, title string) (EmptyResp
 	_, err = makeRequest[EmptyResponse](
 		ctx,
 		c,
+		Operation{Name: "UpdateStreamTitle", Scopes: []Scope{ScopeChannelWrite}},
 		http.MethodPatch,
 		"/public/v1/channels",
 		http.StatusNoContent,
@@ -121,6 +123,7 @@ func (c *Client) UpdateStreamCategory(ctx context.Context, categoryID int) (Empt
 	_, err = makeRequest[EmptyResponse](
 		ctx,
 		c,
+		Operation{Name: "UpdateStreamCategory", Scopes: []Scope{ScopeChannelWrite}},
 		http.MethodPatch,
 		"/public/v1/channels",
 		http.StatusNoContent,
@@ -146,6 +149,7 @@ func (c *Client) UpdateStreamTags(ctx context.Context, tags []string) (EmptyResp
 	_, err = makeRequest[EmptyResponse](
 		ctx,
 		c,
+		Operation{Name: "UpdateStreamTags", Scopes: []Scope{ScopeChannelWrite}},
 		http.MethodPatch,
 		"/public/v1/channels",
 		http.StatusNoContent,
@@ -50,6 +50,7 @@ func (c *Client) SendChatMessage(
 	response, err := makeRequest[ChatResponse](
 		ctx,
 		c,
+		Operation{Name: "SendChatMessage", Scopes: []Scope{ScopeChatWrite}},
 		http.MethodPost,
 		"/public/v1/chat",
 		http.StatusOK,
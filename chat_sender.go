@@ -0,0 +1,242 @@
+package gokick
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	defaultChatMaxMessageLength = 500
+	defaultChatSenderInterval   = time.Second
+	defaultChatSenderQueueSize  = 100
+)
+
+var (
+	ErrChatSenderClosed = errors.New("chat sender closed")
+	ErrEmptyChatMessage = errors.New("chat message is empty")
+)
+
+type ChatSenderOptions struct {
+	// BroadcasterUserID is the channel messages are sent to. Leave it nil to send to the channel of the token owner.
+	BroadcasterUserID *int
+	MessageType       MessageType
+	// MaxMessageLength is the maximum number of characters per message. Longer contents are split.
+	MaxMessageLength int
+	// Interval is the minimum delay between two messages.
+	Interval time.Duration
+	// QueueSize is the number of messages that can wait to be sent before Send blocks.
+	QueueSize int
+}
+
+// ChatSender queues chat messages and sends them one at a time, paced by Interval.
+type ChatSender struct {
+	client    *Client
+	options   ChatSenderOptions
+	queue     chan *chatSendJob
+	ctx       context.Context
+	cancel    context.CancelFunc
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+type chatSendJob struct {
+	ctx              context.Context
+	contents         []string
+	replyToMessageID *string
+	result           chan chatSendResult
+}
+
+type chatSendResult struct {
+	responses []ChatResponse
+	err       error
+}
+
+func NewChatSender(client *Client, options ChatSenderOptions) *ChatSender {
+	if options.MaxMessageLength <= 0 {
+		options.MaxMessageLength = defaultChatMaxMessageLength
+	}
+
+	if options.Interval <= 0 {
+		options.Interval = defaultChatSenderInterval
+	}
+
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultChatSenderQueueSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sender := &ChatSender{
+		client:  client,
+		options: options,
+		queue:   make(chan *chatSendJob, options.QueueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+		stopped: make(chan struct{}),
+	}
+
+	go sender.run()
+
+	return sender
+}
+
+// Send queues a message and waits until it is sent. Contents longer than MaxMessageLength are split
+// into several messages, sent in a row; only the first one replies to replyToMessageID.
+// It returns one ChatResponse per message sent.
+func (s *ChatSender) Send(ctx context.Context, content string, replyToMessageID *string) ([]ChatResponse, error) {
+	contents := splitChatMessage(content, s.options.MaxMessageLength)
+	if len(contents) == 0 {
+		return nil, ErrEmptyChatMessage
+	}
+
+	job := &chatSendJob{
+		ctx:              ctx,
+		contents:         contents,
+		replyToMessageID: replyToMessageID,
+		result:           make(chan chatSendResult, 1),
+	}
+
+	select {
+	case <-s.ctx.Done():
+		return nil, ErrChatSenderClosed
+	default:
+	}
+
+	select {
+	case s.queue <- job:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.ctx.Done():
+		return nil, ErrChatSenderClosed
+	}
+
+	select {
+	case result := <-job.result:
+		return result.responses, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.stopped:
+		select {
+		case result := <-job.result:
+			return result.responses, result.err
+		default:
+			return nil, ErrChatSenderClosed
+		}
+	}
+}
+
+// Close stops the sender. The message being sent is canceled and queued messages fail with ErrChatSenderClosed.
+func (s *ChatSender) Close() error {
+	s.closeOnce.Do(s.cancel)
+	<-s.stopped
+
+	return nil
+}
+
+func (s *ChatSender) run() {
+	defer close(s.stopped)
+
+	var lastSent time.Time
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.drain()
+			return
+		case job := <-s.queue:
+			lastSent = s.process(job, lastSent)
+		}
+	}
+}
+
+func (s *ChatSender) drain() {
+	for {
+		select {
+		case job := <-s.queue:
+			job.result <- chatSendResult{err: ErrChatSenderClosed}
+		default:
+			return
+		}
+	}
+}
+
+func (s *ChatSender) process(job *chatSendJob, lastSent time.Time) time.Time {
+	ctx, cancel := context.WithCancel(job.ctx)
+	defer cancel()
+
+	stop := context.AfterFunc(s.ctx, cancel)
+	defer stop()
+
+	responses := make([]ChatResponse, 0, len(job.contents))
+	replyToMessageID := job.replyToMessageID
+
+	for _, content := range job.contents {
+		err := job.ctx.Err()
+		if err == nil {
+			err = sleepContext(ctx, time.Until(lastSent.Add(s.options.Interval)))
+		}
+
+		if err != nil {
+			job.result <- chatSendResult{responses: responses, err: s.contextError(err)}
+			return lastSent
+		}
+
+		response, err := s.client.SendChatMessage(ctx, s.options.BroadcasterUserID, content, replyToMessageID, s.options.MessageType)
+		lastSent = time.Now()
+		if err != nil {
+			job.result <- chatSendResult{responses: responses, err: s.contextError(err)}
+			return lastSent
+		}
+
+		responses = append(responses, response.Result)
+		replyToMessageID = nil
+	}
+
+	job.result <- chatSendResult{responses: responses}
+
+	return lastSent
+}
+
+func (s *ChatSender) contextError(err error) error {
+	if s.ctx.Err() != nil {
+		return ErrChatSenderClosed
+	}
+
+	return err
+}
+
+// splitChatMessage cuts content into chunks of at most maxLength characters, on whitespace when possible.
+// It returns no chunk when content is only whitespace.
+func splitChatMessage(content string, maxLength int) []string {
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+
+	runes := []rune(content)
+
+	var chunks []string
+	for len(runes) > maxLength {
+		cut := maxLength
+		for i := maxLength; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+
+		if chunk := strings.TrimSpace(string(runes[:cut])); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+
+		runes = []rune(strings.TrimLeftFunc(string(runes[cut:]), unicode.IsSpace))
+	}
+
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+
+	return chunks
+}
@@ -0,0 +1,253 @@
+package gokick_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sentChatMessage struct {
+	BroadcasterUserID int    `json:"broadcaster_user_id"`
+	Content           string `json:"content"`
+	ReplyToMessageID  string `json:"reply_to_message_id"`
+	Type              string `json:"type"`
+}
+
+func setupChatSenderMockClient(t *testing.T) (*gokick.Client, func() []sentChatMessage) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		messages []sentChatMessage
+	)
+
+	kickClient := setupMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var message sentChatMessage
+		err := json.NewDecoder(r.Body).Decode(&message)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"bad request", "data":null}`)
+			return
+		}
+
+		mu.Lock()
+		messages = append(messages, message)
+		id := len(messages)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"message":"success", "data":{"is_sent":true, "message_id":"message-%d"}}`, id)
+	})
+
+	return kickClient, func() []sentChatMessage {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]sentChatMessage(nil), messages...)
+	}
+}
+
+func TestChatSenderError(t *testing.T) {
+	t.Run("closed", func(t *testing.T) {
+		kickClient, _ := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{})
+		require.NoError(t, sender.Close())
+
+		_, err := sender.Send(context.Background(), "message", nil)
+		require.ErrorIs(t, err, gokick.ErrChatSenderClosed)
+	})
+
+	t.Run("closed while waiting", func(t *testing.T) {
+		kickClient, sent := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{Interval: time.Minute})
+
+		_, err := sender.Send(context.Background(), "first", nil)
+		require.NoError(t, err)
+
+		errs := make(chan error, 1)
+		go func() {
+			_, sendErr := sender.Send(context.Background(), "second", nil)
+			errs <- sendErr
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, sender.Close())
+
+		require.ErrorIs(t, <-errs, gokick.ErrChatSenderClosed)
+		assert.Len(t, sent(), 1)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		kickClient, sent := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{Interval: time.Minute})
+		t.Cleanup(func() { sender.Close() })
+
+		_, err := sender.Send(context.Background(), "first", nil)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		t.Cleanup(cancel)
+
+		_, err = sender.Send(ctx, "second", nil)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Len(t, sent(), 1)
+	})
+
+	t.Run("context canceled while queued", func(t *testing.T) {
+		var requests atomic.Int32
+		release := make(chan struct{})
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			<-release
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"message":"success", "data":{"is_sent":true, "message_id":"message"}}`)
+		})
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{Interval: time.Nanosecond})
+		t.Cleanup(func() { sender.Close() })
+
+		errs := make(chan error, 1)
+		go func() {
+			_, sendErr := sender.Send(context.Background(), "first", nil)
+			errs <- sendErr
+		}()
+
+		require.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		queued := make(chan error, 1)
+		go func() {
+			_, sendErr := sender.Send(ctx, "second", nil)
+			queued <- sendErr
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-queued, context.Canceled)
+
+		close(release)
+		require.NoError(t, <-errs)
+
+		_, err := sender.Send(context.Background(), "third", nil)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("empty message", func(t *testing.T) {
+		kickClient, sent := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{MaxMessageLength: 5})
+		t.Cleanup(func() { sender.Close() })
+
+		for _, content := range []string{"", "   ", " \n\t          \n "} {
+			_, err := sender.Send(context.Background(), content, nil)
+			require.ErrorIs(t, err, gokick.ErrEmptyChatMessage)
+		}
+
+		assert.Empty(t, sent())
+	})
+}
+
+func TestChatSenderSuccess(t *testing.T) {
+	t.Run("paces messages", func(t *testing.T) {
+		kickClient, sent := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{
+			BroadcasterUserID: intPtr(117),
+			MessageType:       gokick.MessageTypeBot,
+			Interval:          50 * time.Millisecond,
+		})
+		t.Cleanup(func() { sender.Close() })
+
+		start := time.Now()
+		for i := range 3 {
+			responses, err := sender.Send(context.Background(), fmt.Sprintf("message %d", i), nil)
+			require.NoError(t, err)
+			require.Len(t, responses, 1)
+			assert.Equal(t, fmt.Sprintf("message-%d", i+1), responses[0].MessageID)
+		}
+
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+		messages := sent()
+		require.Len(t, messages, 3)
+		assert.Equal(t, sentChatMessage{BroadcasterUserID: 117, Content: "message 0", Type: "bot"}, messages[0])
+	})
+
+	t.Run("splits long messages", func(t *testing.T) {
+		kickClient, sent := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{
+			MaxMessageLength: 10,
+			Interval:         time.Millisecond,
+		})
+		t.Cleanup(func() { sender.Close() })
+
+		responses, err := sender.Send(context.Background(), "hello world, this is a long message", stringPtr("reply-to"))
+		require.NoError(t, err)
+		require.Len(t, responses, 5)
+
+		messages := sent()
+		contents := make([]string, len(messages))
+		for i := range messages {
+			contents[i] = messages[i].Content
+			assert.LessOrEqual(t, len(messages[i].Content), 10)
+		}
+
+		assert.Equal(t, []string{"hello", "world,", "this is a", "long", "message"}, contents)
+		assert.Equal(t, "reply-to", messages[0].ReplyToMessageID)
+		assert.Empty(t, messages[1].ReplyToMessageID)
+	})
+
+	t.Run("splits words longer than the limit", func(t *testing.T) {
+		kickClient, sent := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{
+			MaxMessageLength: 4,
+			Interval:         time.Millisecond,
+		})
+		t.Cleanup(func() { sender.Close() })
+
+		_, err := sender.Send(context.Background(), strings.Repeat("é", 10), nil)
+		require.NoError(t, err)
+
+		messages := sent()
+		require.Len(t, messages, 3)
+		assert.Equal(t, "éééé", messages[0].Content)
+		assert.Equal(t, "éé", messages[2].Content)
+	})
+
+	t.Run("concurrent senders", func(t *testing.T) {
+		kickClient, sent := setupChatSenderMockClient(t)
+
+		sender := gokick.NewChatSender(kickClient, gokick.ChatSenderOptions{Interval: time.Millisecond})
+		t.Cleanup(func() { sender.Close() })
+
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := sender.Send(context.Background(), fmt.Sprintf("message %d", i), nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, sent(), 10)
+	})
+}
@@ -3,10 +3,12 @@ package gokick
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"sync"
+	"time"
 )
 
 const (
@@ -18,12 +20,25 @@ type Client struct {
 	options   *ClientOptions
 	mu        sync.Mutex
 	callbacks clientCallbacks
+	limiters  map[EndpointGroup]*rateLimiter
+	doer      Doer
+	// poolEntry is the ClientPool entry of the client, kept alive by it so the pool can return an evicted client
+	// while it is still referenced.
+	poolEntry *pooledClient
+
+	userAccessTokenExpiresAt time.Time
+	refresh                  *refreshCall
+	appAccessTokenExpiresAt  time.Time
+	appTokenRenewal          *refreshCall
 }
 
 type onUserAccessTokenRefreshedCallback func(accessToken, refreshToken string)
 
+type onResponseCallback func(ctx context.Context, metadata ResponseMetadata)
+
 type clientCallbacks struct {
 	onUserAccessTokenRefreshed onUserAccessTokenRefreshedCallback
+	onResponse                 onResponseCallback
 }
 
 type ClientOptions struct {
@@ -35,6 +50,23 @@ type ClientOptions struct {
 	AuthBaseURL      string
 	ClientID         string
 	ClientSecret     string
+	RetryPolicy      *RetryPolicy
+	RateLimits       map[EndpointGroup]RateLimit
+	// TokenStore, when set, provides the user tokens (overriding UserAccessToken and UserRefreshToken
+	// once a token has been saved) and persists them after every refresh.
+	TokenStore TokenStore
+	// UserAccessTokenExpiresAt is the expiry of UserAccessToken, when known. The client refreshes the token
+	// TokenRefreshSkew before it expires (one minute by default) instead of waiting for a 401.
+	UserAccessTokenExpiresAt time.Time
+	TokenRefreshSkew         time.Duration
+	// AutoAppAccessToken makes the client fetch an app access token with ClientID and ClientSecret when it has
+	// no token to send, and fetch a new one before it expires or when it is rejected with a 401.
+	AutoAppAccessToken bool
+	// BatchConcurrency is the maximum number of concurrent requests of the bulk lookups, such as GetChannelsByIDs
+	// (4 by default).
+	BatchConcurrency int
+	// Middlewares wrap the HTTP client sending the requests, the first one being the outermost.
+	Middlewares []Middleware
 }
 
 func NewClient(options *ClientOptions) (*Client, error) {
@@ -50,9 +82,33 @@ func NewClient(options *ClientOptions) (*Client, error) {
 		options.HTTPClient = &http.Client{}
 	}
 
+	if options.TokenRefreshSkew == 0 {
+		options.TokenRefreshSkew = defaultTokenRefreshSkew
+	}
+
+	if options.BatchConcurrency == 0 {
+		options.BatchConcurrency = defaultBatchConcurrency
+	}
+
+	if options.TokenStore != nil {
+		token, err := options.TokenStore.Load(context.Background())
+		switch {
+		case errors.Is(err, ErrTokenNotFound):
+		case err != nil:
+			return nil, fmt.Errorf("failed to load token: %w", err)
+		default:
+			options.UserAccessToken = token.AccessToken
+			options.UserRefreshToken = token.RefreshToken
+			options.UserAccessTokenExpiresAt = token.ExpiresAt
+		}
+	}
+
 	return &Client{
-		options: options,
-		mu:      sync.Mutex{},
+		options:                  options,
+		mu:                       sync.Mutex{},
+		limiters:                 newRateLimiters(options.RateLimits),
+		doer:                     chainMiddlewares(options.HTTPClient, options.Middlewares),
+		userAccessTokenExpiresAt: options.UserAccessTokenExpiresAt,
 	}, nil
 }
 
@@ -70,12 +126,14 @@ type authErrorResponse struct {
 func (c *Client) SetAppAccessToken(token string) {
 	c.mu.Lock()
 	c.options.AppAccessToken = token
+	c.appAccessTokenExpiresAt = time.Time{}
 	c.mu.Unlock()
 }
 
 func (c *Client) SetUserAccessToken(token string) {
 	c.mu.Lock()
 	c.options.UserAccessToken = token
+	c.userAccessTokenExpiresAt = time.Time{}
 	c.mu.Unlock()
 }
 
@@ -91,45 +149,84 @@ func (c *Client) OnUserAccessTokenRefreshed(callback onUserAccessTokenRefreshedC
 	c.mu.Unlock()
 }
 
+// OnResponse registers a callback called with the metadata of every response read by the client, successful or not,
+// including the authentication requests. It is called synchronously, before the request returns, with the context
+// of the request: OperationFromContext returns the operation of the request.
+func (c *Client) OnResponse(callback onResponseCallback) {
+	c.mu.Lock()
+	c.callbacks.onResponse = callback
+	c.mu.Unlock()
+}
+
+func (c *Client) notifyResponse(ctx context.Context, metadata ResponseMetadata) {
+	c.mu.Lock()
+	callback := c.callbacks.onResponse
+	c.mu.Unlock()
+
+	if callback != nil {
+		callback(ctx, metadata)
+	}
+}
+
 func (c *Client) buildURL(base, path string) string {
 	return fmt.Sprintf("%s%s", base, path)
 }
 
-func (c *Client) setRequestHeaders(req *http.Request) {
-	var token string
-	if c.options.AppAccessToken != "" {
-		token = c.options.AppAccessToken
-	}
-	if c.options.UserAccessToken != "" {
-		token = c.options.UserAccessToken
-	}
+type tokenKind int
 
-	if token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	}
+const (
+	tokenKindNone tokenKind = iota
+	tokenKindApp
+	tokenKindUser
+	tokenKindBearer
+)
+
+// requestToken is the token sent in the Authorization header of a request.
+type requestToken struct {
+	kind  tokenKind
+	value string
 }
 
-func (c *Client) refreshToken(ctx context.Context) error {
-	token, err := c.RefreshToken(ctx, c.options.UserRefreshToken)
-	if err != nil {
-		return fmt.Errorf("failed to refresh token: %w", err)
+// selectToken returns the token to send: the one chosen for ctx (see WithAppAccessToken, WithUserAccessToken
+// and WithBearerToken), otherwise the user access token when set, and the app access token otherwise.
+func (c *Client) selectToken(ctx context.Context) requestToken {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch selection := tokenSelection(ctx); selection.kind {
+	case tokenKindBearer:
+		return selection
+	case tokenKindApp:
+		return requestToken{kind: tokenKindApp, value: c.options.AppAccessToken}
+	case tokenKindUser:
+		return requestToken{kind: tokenKindUser, value: c.options.UserAccessToken}
+	case tokenKindNone:
 	}
 
-	c.mu.Lock()
-	c.options.UserAccessToken = token.AccessToken
-	c.options.UserRefreshToken = token.RefreshToken
-	c.mu.Unlock()
+	if c.options.UserAccessToken != "" {
+		return requestToken{kind: tokenKindUser, value: c.options.UserAccessToken}
+	}
 
-	if callback := c.callbacks.onUserAccessTokenRefreshed; callback != nil {
-		go callback(token.AccessToken, token.RefreshToken)
+	if c.options.AppAccessToken != "" {
+		return requestToken{kind: tokenKindApp, value: c.options.AppAccessToken}
 	}
 
-	return nil
+	return requestToken{kind: tokenKindNone}
+}
+
+func (c *Client) setRequestHeaders(req *http.Request, token requestToken) {
+	if token.value != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.value))
+	}
 }
 
 type contextKey string
 
-const retryKey contextKey = "retry"
+// authRequestKey marks the requests sent to the OAuth server, which never trigger a token refresh.
+const authRequestKey contextKey = "auth-request"
+
+// tokenSelectionKey holds the requestToken chosen for the requests made with a context.
+const tokenSelectionKey contextKey = "token-selection"
 
 func (c *Client) do(req *http.Request) (*http.Response, error) {
 	var bodyReader *bytes.Reader
@@ -144,34 +241,44 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 		req.Body = io.NopCloser(bodyReader)
 	}
 
+	canRenew := req.Context().Value(authRequestKey) == nil
+	if canRenew {
+		err := c.renewExpiringToken(req.Context())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attempt := 0
 	for {
-		c.setRequestHeaders(req)
+		err := c.waitRateLimit(req)
+		if err != nil {
+			return nil, err
+		}
 
-		response, err := c.options.HTTPClient.Do(req)
+		token := c.selectToken(req.Context())
+		c.setRequestHeaders(req, token)
+
+		response, err := c.doer.Do(req)
 		if err != nil {
 			return nil, err
 		}
 
-		if response.StatusCode == http.StatusUnauthorized && c.canRefreshUserToken() {
-			ctx := req.Context()
-			if ctx.Value(retryKey) == nil {
-				ctx = context.WithValue(ctx, retryKey, true)
-			} else {
-				return response, nil
-			}
-
-			err := c.refreshToken(ctx)
-			if err != nil {
-				return nil, err
-			}
-
-			if bodyReader != nil {
-				_, err := bodyReader.Seek(0, io.SeekStart)
-				if err != nil {
-					return nil, fmt.Errorf("failed to reset request body: %w", err)
-				}
-			}
+		retry, err := c.renewRejectedToken(req, response, token, canRenew, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+		if retry {
+			canRenew = false
+			continue
+		}
 
+		retry, err = c.waitRetry(req, response, attempt, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+		if retry {
+			attempt++
 			continue
 		}
 
@@ -179,7 +286,66 @@ func (c *Client) do(req *http.Request) (*http.Response, error) {
 	}
 }
 
+// renewRejectedToken renews token when response is a 401 and it can be renewed, and reports whether the request
+// must be sent again with the new token.
+func (c *Client) renewRejectedToken(
+	req *http.Request,
+	response *http.Response,
+	token requestToken,
+	canRenew bool,
+	bodyReader *bytes.Reader,
+) (bool, error) {
+	if response.StatusCode != http.StatusUnauthorized || !canRenew || !c.canRenewToken(token.kind) {
+		return false, nil
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+
+	err := c.renewToken(req.Context(), token)
+	if err != nil {
+		return false, err
+	}
+
+	return true, rewindBody(bodyReader)
+}
+
+// waitRetry waits for the delay of the RetryPolicy when response must be retried, and reports whether the request
+// must be sent again.
+func (c *Client) waitRetry(req *http.Request, response *http.Response, attempt int, bodyReader *bytes.Reader) (bool, error) {
+	delay, ok := c.options.RetryPolicy.nextDelay(attempt, response)
+	if !ok {
+		return false, nil
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+
+	err := sleepContext(req.Context(), delay)
+	if err != nil {
+		return false, err
+	}
+
+	return true, rewindBody(bodyReader)
+}
+
+func rewindBody(bodyReader *bytes.Reader) error {
+	if bodyReader == nil {
+		return nil
+	}
+
+	_, err := bodyReader.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("failed to reset request body: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Client) canRefreshUserToken() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	return c.options.ClientID != "" &&
 		c.options.ClientSecret != "" &&
 		c.options.UserRefreshToken != ""
@@ -0,0 +1,177 @@
+package gokick
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+	"weak"
+)
+
+const defaultClientPoolIdleTimeout = 30 * time.Minute
+
+type ClientPoolOptions struct {
+	// ClientOptions is the template of the clients of the pool: the HTTP client, the app credentials, the base URLs,
+	// the retry policy... are shared by every client, and so are the rate limiters of RateLimits. Its user tokens and
+	// TokenStore are ignored. The app access tokens are not shared: each client fetches its own with
+	// AutoAppAccessToken.
+	ClientOptions ClientOptions
+	// TokenStore returns the store of the tokens of a broadcaster. It must persist the tokens across calls,
+	// since an evicted client is rebuilt from it.
+	TokenStore func(broadcasterUserID int) TokenStore
+	// IdleTimeout is the duration after which a client neither returned by Client nor sending requests is evicted
+	// (30 minutes by default). A negative value disables the eviction.
+	IdleTimeout time.Duration
+}
+
+// ClientPool holds one Client per broadcaster, each with its own user tokens and refreshes.
+// Clients are built lazily from the TokenStore of the broadcaster, and evicted once idle. An evicted client still
+// referenced elsewhere is returned again by Client, so a broadcaster never has two clients refreshing the same
+// rotating refresh token. Each client fetches and renews its own app access token when AutoAppAccessToken is set.
+// It is safe for concurrent use.
+type ClientPool struct {
+	options  ClientPoolOptions
+	limiters map[EndpointGroup]*rateLimiter
+	mu       sync.Mutex
+	clients  map[int]*pooledClient
+	// evicted holds the entries evicted from clients, which stay alive as long as their Client does.
+	evicted map[int]weak.Pointer[pooledClient]
+}
+
+type pooledClient struct {
+	ready  chan struct{}
+	client *Client
+	err    error
+	// lastUsed is the time, in Unix nanoseconds, the client was last returned by Client or sent a request.
+	lastUsed atomic.Int64
+}
+
+func (e *pooledClient) touch() {
+	e.lastUsed.Store(time.Now().UnixNano())
+}
+
+// trackActivity is a Middleware keeping the client from being evicted while it sends requests.
+func (e *pooledClient) trackActivity(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		e.touch()
+		return next.Do(req)
+	})
+}
+
+func NewClientPool(options ClientPoolOptions) (*ClientPool, error) {
+	if options.TokenStore == nil {
+		return nil, errors.New("token store must be set on ClientPool")
+	}
+
+	if options.IdleTimeout == 0 {
+		options.IdleTimeout = defaultClientPoolIdleTimeout
+	}
+
+	if options.ClientOptions.HTTPClient == nil {
+		options.ClientOptions.HTTPClient = &http.Client{}
+	}
+
+	return &ClientPool{
+		options:  options,
+		limiters: newRateLimiters(options.ClientOptions.RateLimits),
+		clients:  make(map[int]*pooledClient),
+		evicted:  make(map[int]weak.Pointer[pooledClient]),
+	}, nil
+}
+
+// Client returns the client acting on behalf of a broadcaster, building it on first use.
+func (p *ClientPool) Client(broadcasterUserID int) (*Client, error) {
+	p.mu.Lock()
+	p.evictIdleLocked()
+
+	entry, ok := p.clients[broadcasterUserID]
+	if !ok {
+		// An evicted client still in use is brought back rather than built again.
+		entry = p.evicted[broadcasterUserID].Value()
+		ok = entry != nil
+		if ok {
+			p.clients[broadcasterUserID] = entry
+		}
+		delete(p.evicted, broadcasterUserID)
+	}
+
+	if ok {
+		entry.touch()
+		p.mu.Unlock()
+
+		<-entry.ready
+
+		return entry.client, entry.err
+	}
+
+	entry = &pooledClient{ready: make(chan struct{})}
+	entry.touch()
+	p.clients[broadcasterUserID] = entry
+	p.mu.Unlock()
+
+	options := p.options.ClientOptions
+	options.UserAccessToken = ""
+	options.UserRefreshToken = ""
+	options.UserAccessTokenExpiresAt = time.Time{}
+	options.TokenStore = p.options.TokenStore(broadcasterUserID)
+	options.Middlewares = append(slices.Clone(options.Middlewares), entry.trackActivity)
+
+	entry.client, entry.err = NewClient(&options)
+	if entry.err == nil {
+		entry.client.limiters = p.limiters
+		entry.client.poolEntry = entry
+	} else {
+		entry.err = fmt.Errorf("failed to create client of broadcaster %d: %w", broadcasterUserID, entry.err)
+
+		p.mu.Lock()
+		if p.clients[broadcasterUserID] == entry {
+			delete(p.clients, broadcasterUserID)
+		}
+		p.mu.Unlock()
+	}
+	close(entry.ready)
+
+	return entry.client, entry.err
+}
+
+// Remove drops the client of a broadcaster, e.g. once the broadcaster revoked the access of the app. The next call
+// to Client builds a new one, even if the removed client is still referenced.
+func (p *ClientPool) Remove(broadcasterUserID int) {
+	p.mu.Lock()
+	delete(p.clients, broadcasterUserID)
+	delete(p.evicted, broadcasterUserID)
+	p.mu.Unlock()
+}
+
+// Len returns the number of clients in the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.evictIdleLocked()
+
+	return len(p.clients)
+}
+
+func (p *ClientPool) evictIdleLocked() {
+	if p.options.IdleTimeout < 0 {
+		return
+	}
+
+	deadline := time.Now().Add(-p.options.IdleTimeout).UnixNano()
+	for broadcasterUserID, entry := range p.clients {
+		if entry.lastUsed.Load() < deadline {
+			delete(p.clients, broadcasterUserID)
+			p.evicted[broadcasterUserID] = weak.Make(entry)
+		}
+	}
+
+	for broadcasterUserID, entry := range p.evicted {
+		if entry.Value() == nil {
+			delete(p.evicted, broadcasterUserID)
+		}
+	}
+}
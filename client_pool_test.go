@@ -0,0 +1,220 @@
+package gokick_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupClientPool(t *testing.T, idleTimeout time.Duration) (*kicktest.Server, *gokick.ClientPool, map[int]gokick.TokenStore) {
+	t.Helper()
+
+	return setupClientPoolWithOptions(t, gokick.ClientPoolOptions{IdleTimeout: idleTimeout})
+}
+
+func setupClientPoolWithOptions(
+	t *testing.T,
+	options gokick.ClientPoolOptions,
+) (*kicktest.Server, *gokick.ClientPool, map[int]gokick.TokenStore) {
+	t.Helper()
+
+	server := kicktest.NewServer()
+	t.Cleanup(server.Close)
+
+	stores := make(map[int]gokick.TokenStore)
+	for _, userID := range []int{721956, 117} {
+		server.AddUser(gokick.UserResponse{UserID: userID})
+
+		token := server.IssueUserToken(userID, gokick.ScopeUserRead)
+		store := gokick.NewMemoryTokenStore()
+		require.NoError(t, store.Save(context.Background(), gokick.Token{
+			AccessToken:  token.AccessToken,
+			RefreshToken: token.RefreshToken,
+		}))
+		stores[userID] = store
+	}
+
+	options.ClientOptions.APIBaseURL = server.URL
+	options.ClientOptions.AuthBaseURL = server.URL
+	options.ClientOptions.ClientID = "client-id"
+	options.ClientOptions.ClientSecret = "client-secret"
+	options.TokenStore = func(broadcasterUserID int) gokick.TokenStore {
+		return stores[broadcasterUserID]
+	}
+
+	pool, err := gokick.NewClientPool(options)
+	require.NoError(t, err)
+
+	return server, pool, stores
+}
+
+func TestClientPoolError(t *testing.T) {
+	t.Run("missing token store", func(t *testing.T) {
+		_, err := gokick.NewClientPool(gokick.ClientPoolOptions{})
+		require.EqualError(t, err, "token store must be set on ClientPool")
+	})
+
+	t.Run("token store failure", func(t *testing.T) {
+		pool, err := gokick.NewClientPool(gokick.ClientPoolOptions{
+			TokenStore: func(int) gokick.TokenStore {
+				return &failingTokenStore{loadErr: errors.New("disk failure")}
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = pool.Client(721956)
+		require.EqualError(t, err, "failed to create client of broadcaster 721956: failed to load token: disk failure")
+		assert.Equal(t, 0, pool.Len())
+	})
+}
+
+func TestClientPoolSuccess(t *testing.T) {
+	t.Run("one client per broadcaster", func(t *testing.T) {
+		_, pool, _ := setupClientPool(t, 0)
+
+		for _, userID := range []int{721956, 117} {
+			client, err := pool.Client(userID)
+			require.NoError(t, err)
+
+			users, err := client.GetUsers(context.Background(), gokick.NewUserListFilter())
+			require.NoError(t, err)
+			require.Len(t, users.Result, 1)
+			assert.Equal(t, userID, users.Result[0].UserID)
+		}
+
+		first, err := pool.Client(721956)
+		require.NoError(t, err)
+		second, err := pool.Client(721956)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+		assert.Equal(t, 2, pool.Len())
+	})
+
+	t.Run("concurrent calls share the client", func(t *testing.T) {
+		_, pool, _ := setupClientPool(t, 0)
+
+		clients := make([]*gokick.Client, 10)
+
+		var wg sync.WaitGroup
+		for i := range clients {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				client, err := pool.Client(721956)
+				assert.NoError(t, err)
+				clients[i] = client
+			}()
+		}
+		wg.Wait()
+
+		for _, client := range clients {
+			assert.Same(t, clients[0], client)
+		}
+	})
+
+	t.Run("refreshes are independent", func(t *testing.T) {
+		server, pool, stores := setupClientPool(t, 0)
+
+		before, err := stores[117].Load(context.Background())
+		require.NoError(t, err)
+
+		stale, err := stores[721956].Load(context.Background())
+		require.NoError(t, err)
+		server.ExpireToken(stale.AccessToken)
+
+		client, err := pool.Client(721956)
+		require.NoError(t, err)
+
+		_, err = client.GetUsers(context.Background(), gokick.NewUserListFilter())
+		require.NoError(t, err)
+
+		refreshed, err := stores[721956].Load(context.Background())
+		require.NoError(t, err)
+		assert.NotEqual(t, stale.AccessToken, refreshed.AccessToken)
+
+		after, err := stores[117].Load(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("idle clients are evicted", func(t *testing.T) {
+		_, pool, _ := setupClientPool(t, 20*time.Millisecond)
+
+		_, err := pool.Client(721956)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pool.Len())
+
+		require.Eventually(t, func() bool { return pool.Len() == 0 }, time.Second, 5*time.Millisecond)
+
+		_, err = pool.Client(721956)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pool.Len())
+	})
+
+	t.Run("evicted clients still referenced are returned again", func(t *testing.T) {
+		_, pool, _ := setupClientPool(t, 20*time.Millisecond)
+
+		first, err := pool.Client(721956)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return pool.Len() == 0 }, time.Second, 5*time.Millisecond)
+
+		second, err := pool.Client(721956)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+	})
+
+	t.Run("clients sending requests are not evicted", func(t *testing.T) {
+		_, pool, _ := setupClientPool(t, 50*time.Millisecond)
+
+		client, err := pool.Client(721956)
+		require.NoError(t, err)
+
+		for range 10 {
+			_, err = client.GetUsers(context.Background(), gokick.NewUserListFilter())
+			require.NoError(t, err)
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		assert.Equal(t, 1, pool.Len())
+	})
+
+	t.Run("rate limiters are shared", func(t *testing.T) {
+		_, pool, _ := setupClientPoolWithOptions(t, gokick.ClientPoolOptions{
+			ClientOptions: gokick.ClientOptions{
+				RateLimits: map[gokick.EndpointGroup]gokick.RateLimit{gokick.EndpointGroupRead: {Rate: 0.1, Burst: 1}},
+			},
+		})
+
+		first, err := pool.Client(721956)
+		require.NoError(t, err)
+		_, err = first.GetUsers(context.Background(), gokick.NewUserListFilter())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		second, err := pool.Client(117)
+		require.NoError(t, err)
+		_, err = second.GetUsers(ctx, gokick.NewUserListFilter())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		_, pool, _ := setupClientPool(t, 0)
+
+		_, err := pool.Client(721956)
+		require.NoError(t, err)
+
+		pool.Remove(721956)
+		assert.Equal(t, 0, pool.Len())
+	})
+}
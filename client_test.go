@@ -3,11 +3,14 @@ package gokick_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -109,3 +112,96 @@ func TestClientRefreshTokenSuccess(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type failingTokenStore struct {
+	loadErr error
+	saveErr error
+}
+
+func (s *failingTokenStore) Load(_ context.Context) (gokick.Token, error) {
+	return gokick.Token{}, s.loadErr
+}
+
+func (s *failingTokenStore) Save(_ context.Context, _ gokick.Token) error {
+	return s.saveErr
+}
+
+func TestClientTokenStoreError(t *testing.T) {
+	t.Run("load", func(t *testing.T) {
+		_, err := gokick.NewClient(&gokick.ClientOptions{
+			TokenStore: &failingTokenStore{loadErr: errors.New("disk failure")},
+		})
+		require.EqualError(t, err, "failed to load token: disk failure")
+	})
+
+	t.Run("save", func(t *testing.T) {
+		kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+			UserAccessToken:  "access-token",
+			ClientID:         "client-id",
+			ClientSecret:     "client-secret",
+			UserRefreshToken: "user-refresh-token",
+			HTTPClient:       &http.Client{Transport: &mockRoundTripperRefreshTokenOK{code: http.StatusUnauthorized}},
+			TokenStore:       &failingTokenStore{loadErr: gokick.ErrTokenNotFound, saveErr: errors.New("disk failure")},
+		})
+		require.NoError(t, err)
+
+		_, err = kickClient.GetCategory(context.Background(), 117)
+		require.EqualError(t, err, "failed to make request: failed to save token: disk failure")
+	})
+}
+
+func TestClientTokenStoreSuccess(t *testing.T) {
+	store := gokick.NewMemoryTokenStore()
+	require.NoError(t, store.Save(context.Background(), gokick.Token{
+		AccessToken:  "stored-access-token",
+		RefreshToken: "stored-refresh-token",
+	}))
+
+	var authorizations []string
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/oauth/token" {
+			require.NoError(t, req.ParseForm())
+			assert.Equal(t, "stored-refresh-token", req.PostForm.Get("refresh_token"))
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(bytes.NewBufferString(
+					`{"access_token":"new-access-token","refresh_token":"new-refresh-token","expires_in":7200}`,
+				)),
+			}, nil
+		}
+
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+		if req.Header.Get("Authorization") != "Bearer new-access-token" {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(bytes.NewBufferString("{}"))}, nil
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"data":{}}`))}, nil
+	})}
+
+	kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+		UserAccessToken:  "access-token",
+		UserRefreshToken: "user-refresh-token",
+		ClientID:         "client-id",
+		ClientSecret:     "client-secret",
+		HTTPClient:       client,
+		TokenStore:       store,
+	})
+	require.NoError(t, err)
+
+	_, err = kickClient.GetCategory(context.Background(), 117)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bearer stored-access-token", "Bearer new-access-token"}, authorizations)
+
+	token, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "new-access-token", token.AccessToken)
+	assert.Equal(t, "new-refresh-token", token.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), token.ExpiresAt, time.Minute)
+}
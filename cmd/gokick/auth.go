@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/scorfly/gokick"
+)
+
+const defaultRedirectURL = "http://localhost:3000/oauth/kick/callback"
+
+var allScopes = []gokick.Scope{
+	gokick.ScopeUserRead,
+	gokick.ScopeChannelRead,
+	gokick.ScopeChannelWrite,
+	gokick.ScopeChatWrite,
+	gokick.ScopeStremkeyRead,
+	gokick.ScopeEventSubscribe,
+	gokick.ScopeModerationBan,
+	gokick.ScopeKicksRead,
+}
+
+type authorizationResult struct {
+	code string
+	err  error
+}
+
+// runAuthLogin runs the OAuth 2.1 authorization code flow with PKCE: it serves the redirect URL locally,
+// prints the authorization URL, and exchanges the code it receives for tokens.
+func runAuthLogin(ctx context.Context, a *app, args []string) error {
+	p := a.currentProfile()
+
+	flags := a.newFlagSet("auth login", "auth login [flags]")
+	scopeNames := flags.String("scopes", "", "scopes to request (comma separated), all of them by default")
+	redirectURL := flags.String("redirect-url", firstNonEmpty(p.RedirectURL, defaultRedirectURL), "redirect URL registered for the app")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	scopes, err := parseScopes(*scopeNames)
+	if err != nil {
+		return err
+	}
+
+	redirect, err := url.Parse(*redirectURL)
+	if err != nil {
+		return fmt.Errorf("invalid redirect URL: %w", err)
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	verifier, err := randomString()
+	if err != nil {
+		return err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authorizeURL, err := client.GetAuthorize(*redirectURL, state, base64.RawURLEncoding.EncodeToString(challenge[:]), scopes)
+	if err != nil {
+		return err
+	}
+
+	code, err := a.waitAuthorizationCode(ctx, redirect, state, authorizeURL)
+	if err != nil {
+		return err
+	}
+
+	token, err := client.GetToken(ctx, *redirectURL, code, verifier)
+	if err != nil {
+		return err
+	}
+
+	p.setUserToken(token)
+
+	err = a.saveConfig()
+	if err != nil {
+		return err
+	}
+
+	return a.print(struct {
+		Scope     string `json:"scope"`
+		ExpiresIn int    `json:"expires_in"`
+	}{Scope: token.Scope, ExpiresIn: token.ExpiresIn})
+}
+
+// parseScopes parses the comma separated scopes of the --scopes flag, all of them when empty.
+func parseScopes(value string) ([]gokick.Scope, error) {
+	if value == "" {
+		return allScopes, nil
+	}
+
+	var scopes []gokick.Scope
+	for _, name := range splitList(value) {
+		s, err := gokick.NewScope(name)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, s)
+	}
+
+	return scopes, nil
+}
+
+// waitAuthorizationCode serves the redirect URL locally, prints the authorization URL, and returns the code
+// received once the user authorized the application.
+func (a *app) waitAuthorizationCode(ctx context.Context, redirect *url.URL, state, authorizeURL string) (string, error) {
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %s: %w", redirect.Host, err)
+	}
+
+	results := make(chan authorizationResult, 1)
+	server := &http.Server{
+		Handler:           callbackHandler(redirect.Path, state, results),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	fmt.Fprintf(a.stderr, "Open this URL in your browser to authorize the application:\n\n  %s\n\n", authorizeURL)
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-results:
+		return result.code, result.err
+	}
+}
+
+func callbackHandler(path, state string, results chan<- authorizationResult) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var result authorizationResult
+		switch {
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization failed: %s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("state") != state:
+			result.err = errors.New("authorization failed: invalid state")
+		case query.Get("code") == "":
+			result.err = errors.New("authorization failed: missing code")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Authorization complete, you can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	return mux
+}
+
+func runAuthRefresh(ctx context.Context, a *app, _ []string) error {
+	p := a.currentProfile()
+	if p.UserRefreshToken == "" {
+		return errors.New("no refresh token in the profile, run auth login first")
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	token, err := client.RefreshToken(ctx, p.UserRefreshToken)
+	if err != nil {
+		return err
+	}
+
+	p.setUserToken(token)
+
+	return a.saveConfig()
+}
+
+func runAuthRevoke(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("auth revoke", "auth revoke [flags]")
+	refresh := flags.Bool("refresh", false, "revoke the refresh token instead of the access token")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	p := a.currentProfile()
+
+	tokenType, token := gokick.TokenTypeAccess, p.UserAccessToken
+	if *refresh {
+		tokenType, token = gokick.TokenTypeRefresh, p.UserRefreshToken
+	}
+
+	if token == "" {
+		return errors.New("no token to revoke in the profile")
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.RevokeToken(ctx, tokenType, token)
+	if err != nil {
+		return err
+	}
+
+	p.UserAccessToken = ""
+	p.UserTokenExpiry = time.Time{}
+	if *refresh {
+		p.UserRefreshToken = ""
+	}
+
+	return a.saveConfig()
+}
+
+func runAuthAppToken(ctx context.Context, a *app, _ []string) error {
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	token, err := client.GetAppAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	a.currentProfile().AppAccessToken = token.AccessToken
+
+	return a.saveConfig()
+}
+
+func runAuthStatus(ctx context.Context, a *app, _ []string) error {
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.TokenIntrospect(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func randomString() (string, error) {
+	buffer := make([]byte, 32)
+
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
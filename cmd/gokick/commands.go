@@ -0,0 +1,754 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scorfly/gokick"
+)
+
+func commands() []*command {
+	return []*command{
+		{name: "config", subcommands: []*command{
+			{name: "set", usage: "set [--client-id ID] [--client-secret SECRET] [--api-base-url URL] [--auth-base-url URL] [--redirect-url URL]",
+				summary: "update the credentials and endpoints of the profile", run: runConfigSet},
+			{name: "use", usage: "use PROFILE", summary: "make PROFILE the default profile", run: runConfigUse},
+			{name: "profiles", usage: "profiles", summary: "list the profiles", run: runConfigProfiles},
+		}},
+		{name: "auth", subcommands: []*command{
+			{name: "login", usage: "login [--scopes SCOPES] [--redirect-url URL]",
+				summary: "authorize a user through the browser and store its tokens", run: runAuthLogin},
+			{name: "refresh", usage: "refresh", summary: "refresh the user access token", run: runAuthRefresh},
+			{name: "revoke", usage: "revoke [--refresh]", summary: "revoke the user access (or refresh) token", run: runAuthRevoke},
+			{name: "app-token", usage: "app-token", summary: "fetch and store an app access token", run: runAuthAppToken},
+			{name: "status", usage: "status", summary: "introspect the current token", run: runAuthStatus},
+		}},
+		{name: "channels", subcommands: []*command{
+			{name: "get", usage: "get [--slug SLUGS] [--id IDS]", summary: "get channels, the token owner's by default", run: runChannelsGet},
+		}},
+		{name: "channel", subcommands: []*command{
+			{name: "set-title", usage: "set-title TITLE", summary: "update the stream title", run: runChannelSetTitle},
+			{name: "set-category", usage: "set-category CATEGORY_ID", summary: "update the stream category", run: runChannelSetCategory},
+			{name: "set-tags", usage: "set-tags TAGS", summary: "update the stream tags (comma separated)", run: runChannelSetTags},
+		}},
+		{name: "chat", subcommands: []*command{
+			{name: "send", usage: "send [--broadcaster ID] [--reply-to MESSAGE_ID] [--type bot|user] MESSAGE",
+				summary: "post a chat message", run: runChatSend},
+		}},
+		{name: "ban", usage: "ban --broadcaster ID --user ID [--duration MINUTES] [--reason REASON]",
+			summary: "ban or timeout a user", run: runBan},
+		{name: "unban", usage: "unban --broadcaster ID --user ID", summary: "unban a user", run: runUnban},
+		{name: "subs", subcommands: []*command{
+			{name: "list", usage: "list", summary: "list the event subscriptions", run: runSubsList},
+			{name: "create", usage: "create --events NAMES [--version N] [--broadcaster ID]",
+				summary: "subscribe to events (comma separated names)", run: runSubsCreate},
+			{name: "delete", usage: "delete ID...", summary: "delete event subscriptions", run: runSubsDelete},
+			{name: "reconcile", usage: "reconcile --events NAMES [--version N] --broadcaster ID [--dry-run]",
+				summary: "subscribe to exactly these events, deleting the other subscriptions", run: runSubsReconcile},
+		}},
+		{name: "categories", subcommands: []*command{
+			{name: "search", usage: "search [--page N] [--all] [QUERY]", summary: "search categories", run: runCategoriesSearch},
+			{name: "get", usage: "get CATEGORY_ID", summary: "get a category", run: runCategoriesGet},
+		}},
+		{name: "livestreams", subcommands: []*command{
+			{name: "list", usage: "list [--broadcaster ID] [--category ID] [--language LANG] [--limit N] [--sort viewer_count|started_at]",
+				summary: "list livestreams", run: runLivestreamsList},
+			{name: "stats", usage: "stats", summary: "get livestreams statistics", run: runLivestreamsStats},
+		}},
+		{name: "users", subcommands: []*command{
+			{name: "get", usage: "get [--id IDS]", summary: "get users, the token owner by default", run: runUsersGet},
+		}},
+		{name: "kicks", subcommands: []*command{
+			{name: "leaderboard", usage: "leaderboard [--top N]", summary: "get the kicks leaderboard", run: runKicksLeaderboard},
+		}},
+		{name: "webhook", subcommands: []*command{
+			{name: "keygen", usage: "keygen [--key FILE] [--force]",
+				summary: "generate the key signing simulated webhooks and print its public key", run: runWebhookKeygen},
+			{name: "public-key", usage: "public-key [--key FILE]", summary: "print the public key verifying simulated webhooks",
+				run: runWebhookPublicKey},
+			{name: "send", usage: "send --url URL [--version N] [--set FIELD=VALUE]... [--payload FILE] [--count N] [--rate N] EVENT",
+				summary: "sign and deliver a sample webhook", run: runWebhookSend},
+			{name: "run", usage: "run --url URL [--rate N] SCRIPT", summary: "deliver the webhooks of a JSON script", run: runWebhookRun},
+		}},
+	}
+}
+
+func runConfigSet(_ context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("config set", "config set [flags]")
+	clientID := flags.String("client-id", "", "OAuth client ID")
+	clientSecret := flags.String("client-secret", "", "OAuth client secret")
+	apiBaseURL := flags.String("api-base-url", "", "base URL of the API")
+	authBaseURL := flags.String("auth-base-url", "", "base URL of the OAuth server")
+	redirectURL := flags.String("redirect-url", "", "redirect URL used by auth login")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	p := a.currentProfile()
+	setIfVisited(flags, "client-id", &p.ClientID, *clientID)
+	setIfVisited(flags, "client-secret", &p.ClientSecret, *clientSecret)
+	setIfVisited(flags, "api-base-url", &p.APIBaseURL, *apiBaseURL)
+	setIfVisited(flags, "auth-base-url", &p.AuthBaseURL, *authBaseURL)
+	setIfVisited(flags, "redirect-url", &p.RedirectURL, *redirectURL)
+
+	return a.saveConfig()
+}
+
+func runConfigUse(_ context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("config use", "config use PROFILE")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	a.config.CurrentProfile = flags.Arg(0)
+	a.config.profile(flags.Arg(0))
+
+	return a.saveConfig()
+}
+
+func runConfigProfiles(_ context.Context, a *app, _ []string) error {
+	type profileRow struct {
+		Name    string `json:"name"`
+		Current bool   `json:"current"`
+		User    bool   `json:"has_user_token"`
+		App     bool   `json:"has_app_token"`
+	}
+
+	current := a.config.CurrentProfile
+	if current == "" {
+		current = defaultProfileName
+	}
+
+	rows := []profileRow{}
+	for _, name := range a.config.profileNames() {
+		p := a.config.Profiles[name]
+		rows = append(rows, profileRow{
+			Name:    name,
+			Current: name == current,
+			User:    p.UserAccessToken != "",
+			App:     p.AppAccessToken != "",
+		})
+	}
+
+	return a.print(rows)
+}
+
+func runChannelsGet(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("channels get", "channels get [flags]")
+	slugs := flags.String("slug", "", "channel slugs (comma separated)")
+	ids := flags.String("id", "", "broadcaster user IDs (comma separated)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	broadcasterUserIDs, err := parseInts(*ids)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	filter := gokick.NewChannelListFilter().SetSlug(splitList(*slugs)).SetBroadcasterUserIDs(broadcasterUserIDs)
+
+	response, err := client.GetChannels(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runChannelSetTitle(ctx context.Context, a *app, args []string) error {
+	title, err := a.singleArg("channel set-title", "channel set-title TITLE", args)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateStreamTitle(ctx, title)
+
+	return err
+}
+
+func runChannelSetCategory(ctx context.Context, a *app, args []string) error {
+	value, err := a.singleArg("channel set-category", "channel set-category CATEGORY_ID", args)
+	if err != nil {
+		return err
+	}
+
+	categoryID, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid category ID %q", value)
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateStreamCategory(ctx, categoryID)
+
+	return err
+}
+
+func runChannelSetTags(ctx context.Context, a *app, args []string) error {
+	tags, err := a.singleArg("channel set-tags", "channel set-tags TAGS", args)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateStreamTags(ctx, splitList(tags))
+
+	return err
+}
+
+func runChatSend(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("chat send", "chat send [flags] MESSAGE")
+	broadcaster := flags.Int("broadcaster", 0, "broadcaster user ID, required for user messages")
+	replyTo := flags.String("reply-to", "", "ID of the message to reply to")
+	typeName := flags.String("type", "bot", "message type: bot or user")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	messageType, err := gokick.NewMessageType(*typeName)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.SendChatMessage(
+		ctx,
+		optionalInt(*broadcaster),
+		strings.Join(flags.Args(), " "),
+		optionalString(*replyTo),
+		messageType,
+	)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runBan(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("ban", "ban [flags]")
+	broadcaster := flags.Int("broadcaster", 0, "broadcaster user ID")
+	user := flags.Int("user", 0, "ID of the user to ban")
+	duration := flags.Int("duration", 0, "timeout duration in minutes, permanent ban when not set")
+	reason := flags.String("reason", "", "reason of the ban")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *broadcaster == 0 || *user == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.BanUser(ctx, *broadcaster, *user, optionalInt(*duration), optionalString(*reason))
+
+	return err
+}
+
+func runUnban(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("unban", "unban [flags]")
+	broadcaster := flags.Int("broadcaster", 0, "broadcaster user ID")
+	user := flags.Int("user", 0, "ID of the user to unban")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *broadcaster == 0 || *user == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UnbanUser(ctx, *broadcaster, *user)
+
+	return err
+}
+
+func runSubsList(ctx context.Context, a *app, _ []string) error {
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.GetSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runSubsCreate(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("subs create", "subs create [flags]")
+	events := flags.String("events", "", "event names (comma separated), e.g. chat.message.sent,channel.followed")
+	version := flags.Int("version", 1, "version of the events")
+	broadcaster := flags.Int("broadcaster", 0, "broadcaster user ID, the token owner by default")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	names := splitList(*events)
+	if len(names) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	subscriptions, err := subscriptionRequests(names, *version)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.CreateSubscriptions(ctx, gokick.SubscriptionMethodWebhook, subscriptions, optionalInt(*broadcaster))
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runSubsReconcile(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("subs reconcile", "subs reconcile [flags]")
+	events := flags.String("events", "", "event names (comma separated), e.g. chat.message.sent,channel.followed")
+	version := flags.Int("version", 1, "version of the events")
+	broadcaster := flags.Int("broadcaster", 0, "broadcaster user ID")
+	dryRun := flags.Bool("dry-run", false, "print the changes without applying them")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	names := splitList(*events)
+	if len(names) == 0 || *broadcaster == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	subscriptions, err := subscriptionRequests(names, *version)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.ReconcileSubscriptions(ctx, subscriptions, *broadcaster, gokick.ReconcileSubscriptionsOptions{DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	err = a.print(subscriptionChanges(result))
+	if err != nil {
+		return err
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("failed to subscribe to %d events", len(result.Failed))
+	}
+
+	return nil
+}
+
+// subscriptionChange is a row of the output of subs reconcile.
+type subscriptionChange struct {
+	Action  string `json:"action"`
+	Event   string `json:"event"`
+	Version int    `json:"version"`
+	ID      string `json:"id"`
+	Error   string `json:"error,omitempty"`
+}
+
+func subscriptionChanges(result gokick.SubscriptionsReconciliation) []subscriptionChange {
+	var changes []subscriptionChange
+	for _, subscription := range result.Kept {
+		changes = append(changes, subscriptionChange{
+			Action: "keep", Event: subscription.Event, Version: subscription.Version, ID: subscription.ID,
+		})
+	}
+
+	for _, subscription := range result.Created {
+		changes = append(changes, subscriptionChange{
+			Action: "create", Event: subscription.Name, Version: subscription.Version, ID: subscription.SubscriptionID,
+		})
+	}
+
+	for _, subscription := range result.Failed {
+		changes = append(changes, subscriptionChange{
+			Action: "fail", Event: subscription.Name, Version: subscription.Version, Error: subscription.Error,
+		})
+	}
+
+	for _, subscription := range result.Deleted {
+		changes = append(changes, subscriptionChange{
+			Action: "delete", Event: subscription.Event, Version: subscription.Version, ID: subscription.ID,
+		})
+	}
+
+	return changes
+}
+
+func subscriptionRequests(names []string, version int) ([]gokick.SubscriptionRequest, error) {
+	subscriptions := make([]gokick.SubscriptionRequest, len(names))
+	for i, name := range names {
+		subscriptionName, err := gokick.NewSubscriptionName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions[i] = gokick.SubscriptionRequest{Name: subscriptionName, Version: version}
+	}
+
+	return subscriptions, nil
+}
+
+func runSubsDelete(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("subs delete", "subs delete ID...")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteSubscriptions(ctx, gokick.NewSubscriptionToDeleteFilter().SetIDs(flags.Args()))
+
+	return err
+}
+
+func runCategoriesSearch(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("categories search", "categories search [flags] [QUERY]")
+	page := flags.Int("page", 0, "page number")
+	all := flags.Bool("all", false, "fetch every page, from --page")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	filter := gokick.NewCategoryListFilter()
+	if flags.NArg() > 0 {
+		filter = filter.SetQuery(strings.Join(flags.Args(), " "))
+	}
+
+	if *page > 0 {
+		filter = filter.SetPage(*page)
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	if *all {
+		categories := []gokick.CategoryResponse{}
+		for category, err := range client.Categories(ctx, filter) {
+			if err != nil {
+				return err
+			}
+			categories = append(categories, category)
+		}
+
+		return a.print(categories)
+	}
+
+	response, err := client.GetCategories(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runCategoriesGet(ctx context.Context, a *app, args []string) error {
+	value, err := a.singleArg("categories get", "categories get CATEGORY_ID", args)
+	if err != nil {
+		return err
+	}
+
+	categoryID, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid category ID %q", value)
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.GetCategory(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runLivestreamsList(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("livestreams list", "livestreams list [flags]")
+	broadcaster := flags.Int("broadcaster", 0, "broadcaster user ID")
+	category := flags.Int("category", 0, "category ID")
+	language := flags.String("language", "", "language code")
+	limit := flags.Int("limit", 0, "maximum number of livestreams")
+	sortName := flags.String("sort", "", "sort order: viewer_count or started_at")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	filter := gokick.NewLivestreamListFilter()
+	if *broadcaster != 0 {
+		filter = filter.SetBroadcasterUserIDs(*broadcaster)
+	}
+
+	if *category != 0 {
+		filter = filter.SetCategoryID(*category)
+	}
+
+	if *language != "" {
+		filter = filter.SetLanguage(*language)
+	}
+
+	if *limit != 0 {
+		filter = filter.SetLimit(*limit)
+	}
+
+	if *sortName != "" {
+		sort, err := gokick.NewLivestreamSort(*sortName)
+		if err != nil {
+			return err
+		}
+		filter = filter.SetSort(sort)
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.GetLivestreams(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runLivestreamsStats(ctx context.Context, a *app, _ []string) error {
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.GetLivestreamsStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runUsersGet(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("users get", "users get [flags]")
+	ids := flags.String("id", "", "user IDs (comma separated)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	userIDs, err := parseInts(*ids)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.GetUsers(ctx, gokick.NewUserListFilter().SetIDs(userIDs))
+	if err != nil {
+		return err
+	}
+
+	return a.print(response.Result)
+}
+
+func runKicksLeaderboard(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("kicks leaderboard", "kicks leaderboard [flags]")
+	top := flags.Int("top", 0, "number of entries per period")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	filter := gokick.NewKicksLeaderboardFilter()
+	if *top > 0 {
+		filter = filter.SetTop(*top)
+	}
+
+	client, err := a.kickClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.GetKicksLeaderboard(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if a.output == outputJSON {
+		return a.print(response.Result)
+	}
+
+	type leaderboardRow struct {
+		Period string `json:"period"`
+		gokick.KicksLeaderboardEntry
+	}
+
+	var rows []leaderboardRow
+	for _, period := range []struct {
+		name    string
+		entries []gokick.KicksLeaderboardEntry
+	}{
+		{"week", response.Result.Week},
+		{"month", response.Result.Month},
+		{"lifetime", response.Result.Lifetime},
+	} {
+		for _, entry := range period.entries {
+			rows = append(rows, leaderboardRow{Period: period.name, KicksLeaderboardEntry: entry})
+		}
+	}
+
+	return a.print(rows)
+}
+
+func setIfVisited(flags *flag.FlagSet, name string, target *string, value string) {
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			*target = value
+		}
+	})
+}
+
+// singleArg parses a command taking exactly one positional argument.
+func (a *app) singleArg(cmd, usage string, args []string) (string, error) {
+	flags := a.newFlagSet(cmd, usage)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return "", err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return "", errUsage
+	}
+
+	return flags.Arg(0), nil
+}
+
+func parseInts(value string) ([]int, error) {
+	items := splitList(value)
+
+	ints := make([]int, len(items))
+	for i, item := range items {
+		parsed, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q", item)
+		}
+		ints[i] = parsed
+	}
+
+	return ints, nil
+}
+
+func optionalInt(value int) *int {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/scorfly/gokick"
+)
+
+const defaultProfileName = "default"
+
+type config struct {
+	CurrentProfile string              `json:"current_profile,omitempty"`
+	Profiles       map[string]*profile `json:"profiles"`
+}
+
+type profile struct {
+	ClientID         string    `json:"client_id,omitempty"`
+	ClientSecret     string    `json:"client_secret,omitempty"`
+	UserAccessToken  string    `json:"user_access_token,omitempty"`
+	UserRefreshToken string    `json:"user_refresh_token,omitempty"`
+	UserTokenExpiry  time.Time `json:"user_token_expiry,omitzero"`
+	AppAccessToken   string    `json:"app_access_token,omitempty"`
+	APIBaseURL       string    `json:"api_base_url,omitempty"`
+	AuthBaseURL      string    `json:"auth_base_url,omitempty"`
+	RedirectURL      string    `json:"redirect_url,omitempty"`
+}
+
+func defaultConfigPath() (string, error) {
+	if path := os.Getenv("GOKICK_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config directory: %w", err)
+	}
+
+	return filepath.Join(dir, "gokick", "config.json"), nil
+}
+
+func loadConfig(path string) (*config, error) {
+	cfg := &config{Profiles: make(map[string]*profile)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	err = json.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]*profile)
+	}
+
+	return cfg, nil
+}
+
+// save writes the config atomically, readable by the current user only since it holds secrets.
+func (c *config) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(append(data, '\n'))
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	err = os.Chmod(tmp.Name(), 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
+}
+
+// profile returns the named profile, creating it when it does not exist.
+func (c *config) profile(name string) *profile {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+
+	if name == "" {
+		name = defaultProfileName
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		p = &profile{}
+		c.Profiles[name] = p
+	}
+
+	return p
+}
+
+func (c *config) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// profileTokenStore is the gokick.TokenStore of a profile: saved tokens are written to the config file.
+type profileTokenStore struct {
+	app     *app
+	profile *profile
+}
+
+func (s *profileTokenStore) Load(_ context.Context) (gokick.Token, error) {
+	if s.profile.UserAccessToken == "" && s.profile.UserRefreshToken == "" {
+		return gokick.Token{}, gokick.ErrTokenNotFound
+	}
+
+	return gokick.Token{
+		AccessToken:  s.profile.UserAccessToken,
+		RefreshToken: s.profile.UserRefreshToken,
+		ExpiresAt:    s.profile.UserTokenExpiry,
+	}, nil
+}
+
+func (s *profileTokenStore) Save(_ context.Context, token gokick.Token) error {
+	s.profile.UserAccessToken = token.AccessToken
+	s.profile.UserRefreshToken = token.RefreshToken
+	s.profile.UserTokenExpiry = token.ExpiresAt
+
+	return s.app.saveConfig()
+}
+
+// setUserToken stores a token issued by the OAuth server.
+func (p *profile) setUserToken(token gokick.TokenResponse) {
+	p.UserAccessToken = token.AccessToken
+	p.UserRefreshToken = token.RefreshToken
+	p.UserTokenExpiry = time.Time{}
+	if token.ExpiresIn > 0 {
+		p.UserTokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+}
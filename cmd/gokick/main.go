@@ -0,0 +1,213 @@
+// Command gokick is a command-line client for the KICK API.
+//
+// Tokens and credentials are stored in named profiles in a config file
+// ($XDG_CONFIG_HOME/gokick/config.json by default, or $GOKICK_CONFIG).
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/scorfly/gokick"
+)
+
+type command struct {
+	name        string
+	usage       string
+	summary     string
+	run         func(ctx context.Context, a *app, args []string) error
+	subcommands []*command
+}
+
+type app struct {
+	stdout     io.Writer
+	stderr     io.Writer
+	configPath string
+	config     *config
+	profile    string
+	output     string
+	client     *gokick.Client
+}
+
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	if err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "gokick: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	a := &app{stdout: stdout, stderr: stderr}
+
+	flags := flag.NewFlagSet("gokick", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&a.configPath, "config", "", "path of the config file")
+	flags.StringVar(&a.profile, "profile", "", "name of the profile to use")
+	flags.StringVar(&a.output, "output", outputTable, "output format: table or json")
+	flags.Usage = func() { printUsage(stderr, flags) }
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if a.output != outputTable && a.output != outputJSON {
+		return fmt.Errorf("unknown output format: %s", a.output)
+	}
+
+	if a.configPath == "" {
+		a.configPath, err = defaultConfigPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	a.config, err = loadConfig(a.configPath)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		printUsage(stderr, flags)
+		return errUsage
+	}
+
+	return dispatch(ctx, a, commands(), flags.Args(), "gokick")
+}
+
+func dispatch(ctx context.Context, a *app, available []*command, args []string, path string) error {
+	if len(args) == 0 {
+		printCommands(a.stderr, path, available)
+		return errUsage
+	}
+
+	for _, cmd := range available {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		if cmd.run == nil {
+			return dispatch(ctx, a, cmd.subcommands, args[1:], path+" "+cmd.name)
+		}
+
+		return cmd.run(ctx, a, args[1:])
+	}
+
+	fmt.Fprintf(a.stderr, "unknown command: %s %s\n\n", path, args[0])
+	printCommands(a.stderr, path, available)
+
+	return errUsage
+}
+
+func printUsage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: gokick [flags] <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	flags.PrintDefaults()
+	fmt.Fprintln(w)
+	printCommands(w, "gokick", commands())
+}
+
+func printCommands(w io.Writer, path string, available []*command) {
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range available {
+		if cmd.run == nil {
+			for _, sub := range cmd.subcommands {
+				fmt.Fprintf(w, "  %s %s %s\n      %s\n", path, cmd.name, sub.usage, sub.summary)
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "  %s %s\n      %s\n", path, cmd.usage, cmd.summary)
+	}
+}
+
+// newFlagSet returns the flag set of a command; the usage line is printed on -h.
+func (a *app) newFlagSet(cmd string, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: gokick %s\n", usage)
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+func (a *app) currentProfile() *profile {
+	return a.config.profile(a.profile)
+}
+
+func (a *app) saveConfig() error {
+	return a.config.save(a.configPath)
+}
+
+// kickClient builds a client from the current profile. KICK_CLIENT_ID and KICK_CLIENT_SECRET
+// override the credentials stored in the profile, and refreshed tokens are saved back to it.
+func (a *app) kickClient() (*gokick.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+
+	p := a.currentProfile()
+
+	options := &gokick.ClientOptions{
+		AppAccessToken:   p.AppAccessToken,
+		UserAccessToken:  p.UserAccessToken,
+		UserRefreshToken: p.UserRefreshToken,
+		APIBaseURL:       p.APIBaseURL,
+		AuthBaseURL:      p.AuthBaseURL,
+		ClientID:         envOr("KICK_CLIENT_ID", p.ClientID),
+		ClientSecret:     envOr("KICK_CLIENT_SECRET", p.ClientSecret),
+		// Read-only commands work with the client credentials alone.
+		AutoAppAccessToken: true,
+	}
+
+	options.TokenStore = &profileTokenStore{app: a, profile: p}
+
+	client, err := gokick.NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	a.client = client
+
+	return client, nil
+}
+
+func (a *app) print(v any) error {
+	return printResult(a.stdout, a.output, v)
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
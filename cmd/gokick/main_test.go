@@ -0,0 +1,307 @@
+package main //nolint:testpackage // package main cannot be imported
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCLI(t *testing.T) (*kicktest.Server, string) {
+	t.Helper()
+
+	server := kicktest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.AddUser(gokick.UserResponse{UserID: 721956, Name: "Scorfly"})
+	server.AddChannel(gokick.ChannelResponse{BroadcasterUserID: 721956, Slug: "scorfly", StreamTitle: "title"})
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	runCLI(t, configPath, "config", "set", "--client-id", "client-id", "--client-secret", "client-secret",
+		"--api-base-url", server.URL, "--auth-base-url", server.URL)
+
+	return server, configPath
+}
+
+func runCLI(t *testing.T, configPath string, args ...string) string {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), append([]string{"--config", configPath}, args...), &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+
+	return stdout.String()
+}
+
+func TestRunUsageError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := run(context.Background(), []string{"--config", filepath.Join(t.TempDir(), "config.json"), "unknown"}, &stdout, &stderr)
+	require.ErrorIs(t, err, errUsage)
+	assert.Contains(t, stderr.String(), "unknown command: gokick unknown")
+}
+
+func TestRunConfigSetSuccess(t *testing.T) {
+	_, configPath := setupCLI(t)
+
+	info, err := os.Stat(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "client-id", cfg.profile("").ClientID)
+}
+
+func TestRunAuthAppTokenSuccess(t *testing.T) {
+	server, configPath := setupCLI(t)
+
+	runCLI(t, configPath, "auth", "app-token")
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	token, ok := server.Token(cfg.profile("").AppAccessToken)
+	require.True(t, ok)
+	assert.True(t, token.App)
+}
+
+func TestRunAuthRefreshSuccess(t *testing.T) {
+	server, configPath := setupCLI(t)
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	token := server.IssueUserToken(721956, gokick.ScopeChannelRead)
+	cfg.profile("").UserAccessToken = token.AccessToken
+	cfg.profile("").UserRefreshToken = token.RefreshToken
+	require.NoError(t, cfg.save(configPath))
+
+	runCLI(t, configPath, "auth", "refresh")
+
+	cfg, err = loadConfig(configPath)
+	require.NoError(t, err)
+	assert.NotEqual(t, token.AccessToken, cfg.profile("").UserAccessToken)
+	assert.NotEqual(t, token.RefreshToken, cfg.profile("").UserRefreshToken)
+}
+
+func TestRunChannelsGetSuccess(t *testing.T) {
+	server, configPath := setupCLI(t)
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	cfg.profile("").UserAccessToken = server.IssueUserToken(721956, gokick.ScopeChannelRead).AccessToken
+	require.NoError(t, cfg.save(configPath))
+
+	t.Run("json", func(t *testing.T) {
+		output := runCLI(t, configPath, "--output", "json", "channels", "get", "--slug", "scorfly")
+
+		var channels []gokick.ChannelResponse
+		require.NoError(t, json.Unmarshal([]byte(output), &channels))
+		require.Len(t, channels, 1)
+		assert.Equal(t, 721956, channels[0].BroadcasterUserID)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		output := runCLI(t, configPath, "channels", "get", "--slug", "scorfly")
+		assert.Contains(t, output, "BROADCASTER_USER_ID")
+		assert.Contains(t, output, "721956")
+	})
+}
+
+func TestRunChatSendSuccess(t *testing.T) {
+	server, configPath := setupCLI(t)
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	cfg.profile("").UserAccessToken = server.IssueUserToken(721956, gokick.ScopeChatWrite).AccessToken
+	require.NoError(t, cfg.save(configPath))
+
+	runCLI(t, configPath, "chat", "send", "--type", "user", "--broadcaster", "721956", "hello", "world")
+
+	messages := server.ChatMessages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "hello world", messages[0].Content)
+}
+
+func setupWebhookReceiver(t *testing.T, configPath string) (*httptest.Server, *[]*gokick.KicksGiftedEvent) {
+	t.Helper()
+
+	publicKey := runCLI(t, configPath, "webhook", "keygen")
+
+	provider, err := gokick.NewStaticPublicKeyProvider(publicKey)
+	require.NoError(t, err)
+
+	var (
+		mu    sync.Mutex
+		gifts []*gokick.KicksGiftedEvent
+	)
+
+	handler := gokick.NewWebhookHandler()
+	handler.SetVerifier(&gokick.EventVerifier{PublicKey: provider})
+	handler.OnKicksGifted(func(_ context.Context, event *gokick.KicksGiftedEvent) {
+		mu.Lock()
+		gifts = append(gifts, event)
+		mu.Unlock()
+	})
+
+	receiver := httptest.NewServer(handler)
+	t.Cleanup(receiver.Close)
+
+	return receiver, &gifts
+}
+
+func TestRunWebhookError(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	t.Run("missing key", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := run(context.Background(), []string{"--config", configPath, "webhook", "send", "--url", "http://localhost", "kicks.gifted"},
+			&stdout, &stderr)
+		require.ErrorContains(t, err, "create it with gokick webhook keygen")
+	})
+
+	t.Run("not acknowledged", func(t *testing.T) {
+		runCLI(t, configPath, "webhook", "keygen")
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		t.Cleanup(receiver.Close)
+
+		var stdout, stderr bytes.Buffer
+		err := run(context.Background(), []string{"--config", configPath, "webhook", "send", "--url", receiver.URL, "kicks.gifted"},
+			&stdout, &stderr)
+		require.EqualError(t, err, "1 of 1 webhooks were not acknowledged")
+		assert.Contains(t, stdout.String(), "401")
+	})
+}
+
+func TestRunWebhookSendSuccess(t *testing.T) {
+	_, configPath := setupCLI(t)
+	receiver, gifts := setupWebhookReceiver(t, configPath)
+
+	output := runCLI(t, configPath, "--output", "json", "webhook", "send", "--url", receiver.URL,
+		"--set", "gift.amount=500", "--set", "sender.username=alice", "--count", "2", "--rate", "100", "kicks.gifted")
+
+	var deliveries []webhookDelivery
+	require.NoError(t, json.Unmarshal([]byte(output), &deliveries))
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, http.StatusOK, deliveries[0].Status)
+	assert.NotEqual(t, deliveries[0].MessageID, deliveries[1].MessageID)
+
+	require.Len(t, *gifts, 2)
+	assert.Equal(t, 500, (*gifts)[0].Gift.Amount)
+	assert.Equal(t, "alice", (*gifts)[0].Sender.Username)
+	assert.Equal(t, "Full Send", (*gifts)[0].Gift.Name)
+}
+
+func TestRunWebhookRunSuccess(t *testing.T) {
+	_, configPath := setupCLI(t)
+	receiver, gifts := setupWebhookReceiver(t, configPath)
+
+	script := filepath.Join(t.TempDir(), "script.json")
+	require.NoError(t, os.WriteFile(script, []byte(`[
+		{"event": "channel.followed"},
+		{"event": "kicks.gifted", "set": {"gift.amount": 10}, "count": 2, "delay": "1ms"},
+		{"event": "kicks.gifted", "payload": {"gift": {"amount": 1000}}}
+	]`), 0o600))
+
+	output := runCLI(t, configPath, "webhook", "run", "--url", receiver.URL, script)
+	assert.Contains(t, output, "channel.followed")
+
+	require.Len(t, *gifts, 3)
+	assert.Equal(t, 10, (*gifts)[0].Gift.Amount)
+	assert.Equal(t, 1000, (*gifts)[2].Gift.Amount)
+}
+
+func TestRunSubsReconcileSuccess(t *testing.T) {
+	server, configPath := setupCLI(t)
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	cfg.profile("").UserAccessToken = server.IssueUserToken(721956, gokick.ScopeEventSubscribe).AccessToken
+	require.NoError(t, cfg.save(configPath))
+
+	server.AddSubscription(gokick.EventResponse{ID: "extra", BroadcasterUserID: 721956, Event: "channel.followed", Version: 1})
+
+	t.Run("dry run", func(t *testing.T) {
+		output := runCLI(t, configPath, "subs", "reconcile", "--events", "chat.message.sent", "--broadcaster", "721956", "--dry-run")
+		assert.Contains(t, output, "create")
+		assert.Contains(t, output, "delete")
+		assert.Len(t, server.Subscriptions(), 1)
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+		output := runCLI(t, configPath, "--output", "json", "subs", "reconcile", "--events", "chat.message.sent", "--broadcaster", "721956")
+
+		var changes []subscriptionChange
+		require.NoError(t, json.Unmarshal([]byte(output), &changes))
+		require.Len(t, changes, 2)
+		assert.Equal(t, "create", changes[0].Action)
+		assert.Equal(t, "delete", changes[1].Action)
+		assert.Equal(t, "extra", changes[1].ID)
+
+		subscriptions := server.Subscriptions()
+		require.Len(t, subscriptions, 1)
+		assert.Equal(t, "chat.message.sent", subscriptions[0].Event)
+	})
+}
+
+func TestRunCategoriesSearchSuccess(t *testing.T) {
+	server, configPath := setupCLI(t)
+
+	cfg, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	cfg.profile("").UserAccessToken = server.IssueUserToken(721956).AccessToken
+	require.NoError(t, cfg.save(configPath))
+
+	server.SetCategoriesPageSize(1)
+	server.AddCategory(gokick.CategoryResponse{ID: 1, Name: "Just Chatting"})
+	server.AddCategory(gokick.CategoryResponse{ID: 2, Name: "Fortnite"})
+
+	output := runCLI(t, configPath, "--output", "json", "categories", "search", "--all")
+
+	var categories []gokick.CategoryResponse
+	require.NoError(t, json.Unmarshal([]byte(output), &categories))
+	require.Len(t, categories, 2)
+	assert.Equal(t, "Fortnite", categories[1].Name)
+}
+
+func TestPrintTableSuccess(t *testing.T) {
+	type row struct {
+		Name string `json:"name"`
+	}
+
+	testCases := map[string]struct {
+		value    any
+		expected string
+	}{
+		"nil":                  {value: nil, expected: ""},
+		"nil pointer":          {value: (*row)(nil), expected: ""},
+		"struct":               {value: &row{Name: "scorfly"}, expected: "NAME\nscorfly\n"},
+		"slice with nil items": {value: []*row{{Name: "scorfly"}, nil}, expected: "NAME\nscorfly\n"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			require.NoError(t, printTable(&stdout, tc.value))
+			assert.Equal(t, tc.expected, stdout.String())
+		})
+	}
+}
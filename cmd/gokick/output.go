@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func printResult(w io.Writer, format string, v any) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(v)
+	case outputTable:
+		return printTable(w, v)
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// printTable prints a struct, or a slice of structs, as a table with one column per field.
+// Nested structs are flattened, their columns being prefixed by the field name. Nil values are not printed.
+func printTable(w io.Writer, v any) error {
+	value := reflect.ValueOf(v)
+	if isNil(value) {
+		return nil
+	}
+
+	var rows []reflect.Value
+	if value.Kind() == reflect.Slice {
+		for i := range value.Len() {
+			if !isNil(value.Index(i)) {
+				rows = append(rows, reflect.Indirect(value.Index(i)))
+			}
+		}
+	} else {
+		rows = append(rows, reflect.Indirect(value))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	var elemType reflect.Type
+	if value.Kind() == reflect.Slice {
+		elemType = value.Type().Elem()
+	} else {
+		elemType = reflect.Indirect(value).Type()
+	}
+
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		for _, row := range rows {
+			fmt.Fprintln(tw, formatCell(row))
+		}
+
+		return tw.Flush()
+	}
+
+	fmt.Fprintln(tw, strings.Join(columnNames(elemType, ""), "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(cells(row), "\t"))
+	}
+
+	return tw.Flush()
+}
+
+func isNil(v reflect.Value) bool {
+	return !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil())
+}
+
+func columnNames(t reflect.Type, prefix string) []string {
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := columnName(field)
+		if field.Type.Kind() == reflect.Struct {
+			if field.Anonymous {
+				names = append(names, columnNames(field.Type, prefix)...)
+			} else {
+				names = append(names, columnNames(field.Type, prefix+name+".")...)
+			}
+			continue
+		}
+
+		names = append(names, prefix+name)
+	}
+
+	return names
+}
+
+func columnName(field reflect.StructField) string {
+	tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if tag == "" || tag == "-" {
+		tag = field.Name
+	}
+
+	return strings.ToUpper(tag)
+}
+
+func cells(v reflect.Value) []string {
+	var values []string
+	for i := range v.NumField() {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			values = append(values, cells(field)...)
+			continue
+		}
+
+		values = append(values, formatCell(field))
+	}
+
+	return values
+}
+
+func formatCell(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]string, v.Len())
+		for i := range v.Len() {
+			items[i] = formatCell(v.Index(i))
+		}
+
+		return strings.Join(items, ",")
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+
+		return formatCell(v.Elem())
+	case reflect.Struct:
+		return fmt.Sprintf("%+v", v.Interface())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+)
+
+const webhookKeyBits = 2048
+
+// webhookStep is a step of a script run by webhook run.
+type webhookStep struct {
+	Event   string `json:"event"`
+	Version int    `json:"version"`
+	// Payload replaces the sample payload of the event.
+	Payload json.RawMessage `json:"payload"`
+	// Set overrides fields of the payload, by dotted path (e.g. "gift.amount").
+	Set   map[string]any `json:"set"`
+	Count int            `json:"count"`
+	// Delay is waited before the step, e.g. "2s".
+	Delay string `json:"delay"`
+}
+
+type webhookDelivery struct {
+	Event     string `json:"event"`
+	MessageID string `json:"message_id"`
+	Status    int    `json:"status"`
+	Duration  string `json:"duration"`
+}
+
+// webhookSimulator signs webhooks with a local key and POSTs them to url, at most rate per second when set.
+type webhookSimulator struct {
+	privateKey *rsa.PrivateKey
+	url        string
+	interval   time.Duration
+	last       time.Time
+}
+
+func runWebhookKeygen(_ context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("webhook keygen", "webhook keygen [flags]")
+	keyPath := flags.String("key", a.defaultWebhookKeyPath(), "file of the private key")
+	force := flags.Bool("force", false, "replace an existing key")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(*keyPath)
+	if err == nil && !*force {
+		return fmt.Errorf("key %s already exists, use --force to replace it", *keyPath)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, webhookKeyBits)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(*keyPath), 0o700)
+	if err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+
+	err = os.WriteFile(*keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write key: %w", err)
+	}
+
+	return printPublicKey(a, privateKey)
+}
+
+func runWebhookPublicKey(_ context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("webhook public-key", "webhook public-key [--key FILE]")
+	keyPath := flags.String("key", a.defaultWebhookKeyPath(), "file of the private key")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := loadWebhookKey(*keyPath)
+	if err != nil {
+		return err
+	}
+
+	return printPublicKey(a, privateKey)
+}
+
+func runWebhookSend(ctx context.Context, a *app, args []string) error {
+	step := webhookStep{Set: make(map[string]any)}
+
+	flags := a.newFlagSet("webhook send", "webhook send --url URL [flags] EVENT")
+	url := flags.String("url", "", "URL receiving the webhooks")
+	keyPath := flags.String("key", a.defaultWebhookKeyPath(), "file of the private key")
+	rate := flags.Float64("rate", 0, "webhooks per second, as fast as possible by default")
+	payloadPath := flags.String("payload", "", "file of a JSON payload replacing the sample payload")
+	flags.IntVar(&step.Version, "version", 1, "version of the event")
+	flags.IntVar(&step.Count, "count", 1, "number of webhooks to send")
+	flags.Func("set", "override a field of the payload, e.g. gift.amount=500 (repeatable)", func(value string) error {
+		path, raw, ok := strings.Cut(value, "=")
+		if !ok {
+			return errors.New("expected FIELD=VALUE")
+		}
+
+		step.Set[path] = parseFieldValue(raw)
+
+		return nil
+	})
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *url == "" || flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+	step.Event = flags.Arg(0)
+
+	if *payloadPath != "" {
+		step.Payload, err = os.ReadFile(*payloadPath)
+		if err != nil {
+			return fmt.Errorf("failed to read payload: %w", err)
+		}
+	}
+
+	simulator, err := newWebhookSimulator(*keyPath, *url, *rate)
+	if err != nil {
+		return err
+	}
+
+	return simulator.run(ctx, a, []webhookStep{step})
+}
+
+func runWebhookRun(ctx context.Context, a *app, args []string) error {
+	flags := a.newFlagSet("webhook run", "webhook run --url URL [flags] SCRIPT")
+	url := flags.String("url", "", "URL receiving the webhooks")
+	keyPath := flags.String("key", a.defaultWebhookKeyPath(), "file of the private key")
+	rate := flags.Float64("rate", 0, "webhooks per second, as fast as possible by default")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *url == "" || flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read script: %w", err)
+	}
+
+	var steps []webhookStep
+	err = json.Unmarshal(data, &steps)
+	if err != nil {
+		return fmt.Errorf("failed to parse script: %w", err)
+	}
+
+	simulator, err := newWebhookSimulator(*keyPath, *url, *rate)
+	if err != nil {
+		return err
+	}
+
+	return simulator.run(ctx, a, steps)
+}
+
+func newWebhookSimulator(keyPath string, url string, rate float64) (*webhookSimulator, error) {
+	privateKey, err := loadWebhookKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	simulator := &webhookSimulator{privateKey: privateKey, url: url}
+	if rate > 0 {
+		simulator.interval = time.Duration(float64(time.Second) / rate)
+	}
+
+	return simulator, nil
+}
+
+// run delivers the webhooks of steps and prints the deliveries. It fails when a webhook is not acknowledged
+// with a 2xx, once every webhook has been sent.
+func (s *webhookSimulator) run(ctx context.Context, a *app, steps []webhookStep) error {
+	var deliveries []webhookDelivery
+	for i, step := range steps {
+		stepDeliveries, err := s.runStep(ctx, step)
+		deliveries = append(deliveries, stepDeliveries...)
+		if err != nil {
+			_ = a.print(deliveries)
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	err := a.print(deliveries)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, delivery := range deliveries {
+		if delivery.Status < 200 || delivery.Status > 299 {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d webhooks were not acknowledged", failed, len(deliveries))
+	}
+
+	return nil
+}
+
+func (s *webhookSimulator) runStep(ctx context.Context, step webhookStep) ([]webhookDelivery, error) {
+	subscriptionName, err := gokick.NewSubscriptionName(step.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := buildWebhookPayload(subscriptionName, step)
+	if err != nil {
+		return nil, err
+	}
+
+	if step.Delay != "" {
+		delay, err := time.ParseDuration(step.Delay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse delay: %w", err)
+		}
+
+		err = sleep(ctx, delay)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	count := max(step.Count, 1)
+
+	deliveries := make([]webhookDelivery, 0, count)
+	for range count {
+		delivery, err := s.send(ctx, kicktest.Webhook{Subscription: subscriptionName, Version: step.Version, Payload: payload})
+		if err != nil {
+			return deliveries, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (s *webhookSimulator) send(ctx context.Context, webhook kicktest.Webhook) (webhookDelivery, error) {
+	if !s.last.IsZero() {
+		err := sleep(ctx, time.Until(s.last.Add(s.interval)))
+		if err != nil {
+			return webhookDelivery{}, err
+		}
+	}
+	s.last = time.Now()
+
+	request, err := kicktest.NewWebhookRequest(ctx, s.privateKey, s.url, webhook)
+	if err != nil {
+		return webhookDelivery{}, err
+	}
+
+	start := time.Now()
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return webhookDelivery{}, fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	response.Body.Close()
+
+	return webhookDelivery{
+		Event:     webhook.Subscription.String(),
+		MessageID: request.Header.Get("Kick-Event-Message-Id"),
+		Status:    response.StatusCode,
+		Duration:  time.Since(start).Round(time.Millisecond).String(),
+	}, nil
+}
+
+// buildWebhookPayload returns the payload of step: its payload, or the sample payload of the event, with the
+// fields of step.Set overridden.
+func buildWebhookPayload(subscriptionName gokick.SubscriptionName, step webhookStep) (json.RawMessage, error) {
+	payload := step.Payload
+	if payload == nil {
+		var err error
+		payload, err = json.Marshal(kicktest.SamplePayload(subscriptionName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+	}
+
+	if len(step.Set) == 0 {
+		return payload, nil
+	}
+
+	var document any
+	err := json.Unmarshal(payload, &document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	for path, value := range step.Set {
+		document, err = setField(document, strings.Split(path, "."), value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set %s: %w", path, err)
+		}
+	}
+
+	return json.Marshal(document)
+}
+
+// setField sets the field at path in document, creating the missing objects, and returns the updated document.
+func setField(document any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	switch node := document.(type) {
+	case map[string]any:
+		child, err := setField(node[path[0]], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[path[0]] = child
+
+		return node, nil
+	case []any:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 || index >= len(node) {
+			return nil, fmt.Errorf("invalid index %s", path[0])
+		}
+
+		node[index], err = setField(node[index], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	case nil:
+		return setField(make(map[string]any), path, value)
+	default:
+		return nil, fmt.Errorf("%s is not an object", path[0])
+	}
+}
+
+// parseFieldValue parses value as JSON (a number, a boolean, an object...), as a string otherwise.
+func parseFieldValue(value string) any {
+	var parsed any
+	if json.Unmarshal([]byte(value), &parsed) == nil {
+		return parsed
+	}
+
+	return value
+}
+
+func (a *app) defaultWebhookKeyPath() string {
+	return filepath.Join(filepath.Dir(a.configPath), "webhook_key.pem")
+}
+
+func loadWebhookKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("key %s does not exist, create it with gokick webhook keygen", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode key %s", path)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key: %w", err)
+	}
+
+	privateKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key %s is not an RSA key", path)
+	}
+
+	return privateKey, nil
+}
+
+func printPublicKey(a *app, privateKey *rsa.PrivateKey) error {
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	_, err = a.stdout.Write(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	return err
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
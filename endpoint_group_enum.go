@@ -0,0 +1,44 @@
+package gokick
+
+import (
+	"fmt"
+)
+
+type EndpointGroup int
+
+const (
+	EndpointGroupRead       EndpointGroup = iota // read
+	EndpointGroupWrite                           // write
+	EndpointGroupChat                            // chat
+	EndpointGroupModeration                      // moderation
+)
+
+func NewEndpointGroup(group string) (EndpointGroup, error) {
+	switch group {
+	case "read":
+		return EndpointGroupRead, nil
+	case "write":
+		return EndpointGroupWrite, nil
+	case "chat":
+		return EndpointGroupChat, nil
+	case "moderation":
+		return EndpointGroupModeration, nil
+	default:
+		return 0, fmt.Errorf("unknown endpoint group: %s", group)
+	}
+}
+
+func (g EndpointGroup) String() string {
+	switch g {
+	case EndpointGroupRead:
+		return "read"
+	case EndpointGroupWrite:
+		return "write"
+	case EndpointGroupChat:
+		return "chat"
+	case EndpointGroupModeration:
+		return "moderation"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,41 @@
+package gokick_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEndpointGroupError(t *testing.T) {
+	testCases := map[string]string{
+		"empty":         "",
+		"not supported": "not supported",
+	}
+
+	for name, value := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := gokick.NewEndpointGroup(value)
+			assert.EqualError(t, err, fmt.Sprintf("unknown endpoint group: %s", value))
+		})
+	}
+}
+
+func TestNewEndpointGroupSuccess(t *testing.T) {
+	testCases := map[string]gokick.EndpointGroup{
+		"read":       gokick.EndpointGroupRead,
+		"write":      gokick.EndpointGroupWrite,
+		"chat":       gokick.EndpointGroupChat,
+		"moderation": gokick.EndpointGroupModeration,
+	}
+
+	for name, value := range testCases {
+		t.Run(name, func(t *testing.T) {
+			group, err := gokick.NewEndpointGroup(value.String())
+			require.NoError(t, err)
+			assert.Equal(t, group, value)
+		})
+	}
+}
@@ -1,11 +1,34 @@
 package gokick
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Errors matched by the Error of a request, with errors.Is, according to its status code.
+var (
+	// ErrUnauthorized matches the 401 errors: the token is invalid, expired or revoked.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden matches the 403 errors: the token lacks a scope, or the action is not allowed.
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound matches the 404 errors.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited matches the 429 errors. Error.RetryAfter tells when to try again, when KICK sends it.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer matches the 5xx errors: KICK is down or failing.
+	ErrServer = errors.New("server error")
+)
 
 type Error struct {
 	code        int
 	message     string
 	description string
+	body        []byte
+	method      string
+	path        string
+	retryAfter  time.Duration
 }
 
 func NewError(code int, message string) Error {
@@ -17,6 +40,19 @@ func (e Error) WithDescription(description string) Error {
 	return e
 }
 
+// withResponse records the request answered with the error, and its raw response.
+func (e Error) withResponse(req *http.Request, resp *http.Response, body []byte) Error {
+	e.method = req.Method
+	e.path = req.URL.Path
+	e.body = body
+
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		e.retryAfter = retryAfter
+	}
+
+	return e
+}
+
 func (e Error) Code() int {
 	return e.code
 }
@@ -29,6 +65,45 @@ func (e Error) Description() string {
 	return e.description
 }
 
+// Body returns the raw body of the response, empty when the error was not returned by a request.
+func (e Error) Body() []byte {
+	return e.body
+}
+
+// Method returns the method of the request, e.g. "GET".
+func (e Error) Method() string {
+	return e.method
+}
+
+// Path returns the path of the request, without its query, e.g. "/public/v1/channels".
+func (e Error) Path() string {
+	return e.path
+}
+
+// RetryAfter returns the delay of the Retry-After header of the response, 0 when it is missing.
+func (e Error) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// Is matches the error with ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited and ErrServer according to its
+// status code.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.code == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.code == http.StatusForbidden
+	case ErrNotFound:
+		return e.code == http.StatusNotFound
+	case ErrRateLimited:
+		return e.code == http.StatusTooManyRequests
+	case ErrServer:
+		return e.code >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
 func (e Error) Error() string {
 	if e.description == "" {
 		return fmt.Sprintf("Error %d: %s", e.code, e.message)
@@ -36,3 +111,17 @@ func (e Error) Error() string {
 		return fmt.Sprintf("Error %d: %s (%s)", e.code, e.message, e.description)
 	}
 }
+
+// causedError has the message of err, and is also matched as cause by errors.Is and errors.As.
+type causedError struct {
+	err   error
+	cause error
+}
+
+func (e causedError) Error() string {
+	return e.err.Error()
+}
+
+func (e causedError) Unwrap() []error {
+	return []error{e.err, e.cause}
+}
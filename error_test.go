@@ -1,7 +1,14 @@
 package gokick_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/scorfly/gokick"
 	"github.com/stretchr/testify/assert"
@@ -31,3 +38,112 @@ func TestNewError(t *testing.T) {
 		assert.EqualError(t, err, "Error 401: not authorized (invalid scope)")
 	})
 }
+
+func TestErrorIs(t *testing.T) {
+	sentinels := []error{gokick.ErrUnauthorized, gokick.ErrForbidden, gokick.ErrNotFound, gokick.ErrRateLimited, gokick.ErrServer}
+
+	testCases := map[string]struct {
+		code     int
+		expected error
+	}{
+		"unauthorized":   {code: http.StatusUnauthorized, expected: gokick.ErrUnauthorized},
+		"forbidden":      {code: http.StatusForbidden, expected: gokick.ErrForbidden},
+		"not found":      {code: http.StatusNotFound, expected: gokick.ErrNotFound},
+		"rate limited":   {code: http.StatusTooManyRequests, expected: gokick.ErrRateLimited},
+		"internal error": {code: http.StatusInternalServerError, expected: gokick.ErrServer},
+		"bad gateway":    {code: http.StatusBadGateway, expected: gokick.ErrServer},
+		"bad request":    {code: http.StatusBadRequest},
+		"conflict":       {code: http.StatusConflict},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := fmt.Errorf("failed to get channels: %w", gokick.NewError(tc.code, "message"))
+
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tc.expected, errors.Is(err, sentinel), sentinel.Error())
+			}
+		})
+	}
+}
+
+func TestRequestErrorSuccess(t *testing.T) {
+	t.Run("error response", func(t *testing.T) {
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"message":"too many requests", "data":null}`)
+		})
+
+		_, err := kickClient.GetChannels(context.Background(), gokick.NewChannelListFilter().SetSlug([]string{"scorfly"}))
+		require.EqualError(t, err, "Error 429: too many requests")
+		require.ErrorIs(t, err, gokick.ErrRateLimited)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.MethodGet, kickError.Method())
+		assert.Equal(t, "/public/v1/channels", kickError.Path())
+		assert.Equal(t, 30*time.Second, kickError.RetryAfter())
+		assert.JSONEq(t, `{"message":"too many requests", "data":null}`, string(kickError.Body()))
+	})
+
+	t.Run("auth error response", func(t *testing.T) {
+		kickClient := setupMockAuthClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_grant", "error_description":"token revoked"}`)
+		})
+
+		_, err := kickClient.RefreshToken(context.Background(), "refresh-token")
+		require.EqualError(t, err, "Error 401: invalid_grant (token revoked)")
+		require.ErrorIs(t, err, gokick.ErrUnauthorized)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.MethodPost, kickError.Method())
+		assert.Equal(t, "/oauth/token", kickError.Path())
+		assert.Zero(t, kickError.RetryAfter())
+	})
+
+	t.Run("not JSON error response", func(t *testing.T) {
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `<html>Bad Gateway</html>`)
+		})
+
+		_, err := kickClient.GetChannels(context.Background(), gokick.NewChannelListFilter())
+		require.EqualError(t, err, `failed to unmarshal error response (KICK status code: 502 and body "<html>Bad Gateway</html>"): `+
+			`invalid character '<' looking for beginning of value`)
+		require.ErrorIs(t, err, gokick.ErrServer)
+
+		var syntaxError *json.SyntaxError
+		require.ErrorAs(t, err, &syntaxError)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusBadGateway, kickError.Code())
+		assert.Equal(t, "<html>Bad Gateway</html>", string(kickError.Body()))
+	})
+
+	t.Run("not JSON response", func(t *testing.T) {
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, `117`)
+		})
+
+		_, err := kickClient.GetChannels(context.Background(), gokick.NewChannelListFilter())
+
+		var typeError *json.UnmarshalTypeError
+		require.ErrorAs(t, err, &typeError)
+		assert.NotErrorIs(t, err, gokick.ErrServer)
+	})
+
+	t.Run("network error", func(t *testing.T) {
+		kickClient := setupTimeoutMockClient(t)
+
+		_, err := kickClient.GetChannels(context.Background(), gokick.NewChannelListFilter())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var urlError *url.Error
+		require.ErrorAs(t, err, &urlError)
+		assert.Equal(t, "Get", urlError.Op)
+	})
+}
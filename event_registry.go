@@ -0,0 +1,102 @@
+package gokick
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// UnknownEvent is the payload of a webhook whose event type, or version, is not registered (see RegisterEventType),
+// e.g. an event KICK shipped after this package was released.
+type UnknownEvent struct {
+	// Name is the event type, from Kick-Event-Type.
+	Name    string
+	Version string
+	Raw     json.RawMessage
+}
+
+type eventConstructor func() interface{}
+
+// eventTypes holds the constructors of the payloads, by event type and version.
+var eventTypes = struct {
+	mu           sync.RWMutex
+	constructors map[string]map[string]eventConstructor
+}{
+	constructors: map[string]map[string]eventConstructor{
+		SubscriptionNameChatMessage.String(): {
+			"1": func() interface{} { return new(ChatMessageEvent) },
+		},
+		SubscriptionNameChannelFollow.String(): {
+			"1": func() interface{} { return new(ChannelFollowEvent) },
+		},
+		SubscriptionNameChannelSubscriptionRenewal.String(): {
+			"1": func() interface{} { return new(ChannelSubscriptionRenewalEvent) },
+		},
+		SubscriptionNameChannelSubscriptionGifts.String(): {
+			"1": func() interface{} { return new(ChannelSubscriptionGiftsEvent) },
+		},
+		SubscriptionNameChannelSubscriptionCreated.String(): {
+			"1": func() interface{} { return new(ChannelSubscriptionCreatedEvent) },
+		},
+		SubscriptionNameLivestreamStatusUpdated.String(): {
+			"1": func() interface{} { return new(LivestreamStatusUpdatedEvent) },
+		},
+		SubscriptionNameLivestreamMetadataUpdated.String(): {
+			"1": func() interface{} { return new(LivestreamMetadataUpdatedEvent) },
+		},
+		SubscriptionNameModerationBanned.String(): {
+			"1": func() interface{} { return new(ModerationBannedEvent) },
+		},
+		SubscriptionNameKicksGifted.String(): {
+			"1": func() interface{} { return new(KicksGiftedEvent) },
+		},
+	},
+}
+
+// RegisterEventType makes the webhooks of the event type name, in version, decode into the value returned by
+// constructor, which must be a pointer. It replaces the type registered by this package, if any.
+// It panics when constructor is nil.
+func RegisterEventType(name string, version string, constructor func() interface{}) {
+	if constructor == nil {
+		panic("gokick: RegisterEventType called with a nil constructor")
+	}
+
+	eventTypes.mu.Lock()
+	defer eventTypes.mu.Unlock()
+
+	if eventTypes.constructors[name] == nil {
+		eventTypes.constructors[name] = make(map[string]eventConstructor)
+	}
+	eventTypes.constructors[name][version] = constructor
+}
+
+func lookupEventType(name string, version string) (eventConstructor, bool) {
+	eventTypes.mu.RLock()
+	defer eventTypes.mu.RUnlock()
+
+	constructor, ok := eventTypes.constructors[name][version]
+	return constructor, ok
+}
+
+// decodeEvent decodes the payload of a webhook, into an *UnknownEvent when its type is not registered.
+func decodeEvent(name string, version string, body []byte) (interface{}, error) {
+	constructor, ok := lookupEventType(name, version)
+	if !ok {
+		var raw json.RawMessage
+		err := json.Unmarshal(body, &raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event: %v", err)
+		}
+
+		return &UnknownEvent{Name: name, Version: version, Raw: raw}, nil
+	}
+
+	event := constructor()
+
+	err := json.Unmarshal(body, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %v", err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,57 @@
+package gokick_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type channelRaidedEvent struct {
+	Viewers int `json:"viewers"`
+}
+
+func TestRegisterEventTypeError(t *testing.T) {
+	assert.PanicsWithValue(t, "gokick: RegisterEventType called with a nil constructor", func() {
+		gokick.RegisterEventType("channel.raided", "1", nil)
+	})
+}
+
+func TestRegisterEventTypeSuccess(t *testing.T) {
+	gokick.RegisterEventType("test.channel.raided", "1", func() interface{} { return new(channelRaidedEvent) })
+
+	verifier := &gokick.EventVerifier{SkipSignatureValidation: true}
+
+	t.Run("registered version", func(t *testing.T) {
+		event, err := verifier.VerifyEvent(
+			context.Background(),
+			gokick.WebhookHeaders{Name: "test.channel.raided", Version: "1"},
+			[]byte(`{"viewers":10}`),
+		)
+		require.NoError(t, err)
+		require.IsType(t, &channelRaidedEvent{}, event.Payload)
+		assert.Equal(t, 10, event.Payload.(*channelRaidedEvent).Viewers)
+	})
+
+	t.Run("unregistered version", func(t *testing.T) {
+		event, err := verifier.VerifyEvent(
+			context.Background(),
+			gokick.WebhookHeaders{Name: "test.channel.raided", Version: "2"},
+			[]byte(`{"viewers":10}`),
+		)
+		require.NoError(t, err)
+		require.IsType(t, &gokick.UnknownEvent{}, event.Payload)
+		assert.Equal(t, "2", event.Payload.(*gokick.UnknownEvent).Version)
+	})
+
+	t.Run("invalid payload of unknown event", func(t *testing.T) {
+		_, err := verifier.VerifyEvent(
+			context.Background(),
+			gokick.WebhookHeaders{Name: "test.channel.unknown", Version: "1"},
+			[]byte(`invalid JSON`),
+		)
+		require.EqualError(t, err, "failed to unmarshal event: invalid character 'i' looking for beginning of value")
+	})
+}
@@ -0,0 +1,164 @@
+package gokick
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// EventVerifier verifies and parses KICK webhooks. The zero value verifies signatures with the key KICK used
+// when this package was released (see PublicKeyProvider to follow key rotations) and does not protect against
+// replays.
+type EventVerifier struct {
+	// PublicKey provides the key verifying the signatures, KickEventPublicKey when nil.
+	PublicKey PublicKeyProvider
+	// SkipSignatureValidation disables the signature verification. Only meant for tests.
+	SkipSignatureValidation bool
+	// ReplayGuard rejects old and already received events, when set.
+	ReplayGuard *ReplayGuard
+	// Now returns the current time compared to the timestamp of the events, ReplayGuard.Now (or time.Now) when nil.
+	Now func() time.Time
+
+	// useGlobals makes the verifier honor the deprecated package-level DefaultEventPublicKey and
+	// SkipSignatureValidation.
+	useGlobals bool
+}
+
+// defaultEventVerifier returns the verifier used by the package-level functions, which honors the deprecated
+// package-level variables.
+func defaultEventVerifier() *EventVerifier {
+	return &EventVerifier{useGlobals: true}
+}
+
+// Verify verifies the signature of a webhook and, with a ReplayGuard, that it is neither too old
+// (ErrEventTooOld) nor already received (ErrEventReplayed).
+// The headers are read as ParseWebhookHeaders does.
+func (v *EventVerifier) Verify(ctx context.Context, header http.Header, body []byte) error {
+	return v.verifyHeaders(ctx, readWebhookHeaders(header), body)
+}
+
+// ParseRequest verifies a webhook request and decodes its payload, e.g. into a *ChatMessageEvent.
+func (v *EventVerifier) ParseRequest(request *http.Request) (interface{}, error) {
+	event, err := v.ParseRequestEvent(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.Payload, nil
+}
+
+// ParseRequestEvent verifies a webhook request and decodes it into an Event, with the metadata of the delivery.
+func (v *EventVerifier) ParseRequestEvent(request *http.Request) (*Event, error) {
+	if request == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	headers, err := ParseWebhookHeaders(request.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %v", err)
+	}
+
+	return v.VerifyEvent(request.Context(), headers, body)
+}
+
+// VerifyEvent verifies a webhook from its parsed headers and decodes it into an Event.
+func (v *EventVerifier) VerifyEvent(ctx context.Context, headers WebhookHeaders, body []byte) (*Event, error) {
+	err := v.verifyHeaders(ctx, headers, body)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := newEvent(headers, body)
+	if err != nil {
+		return nil, errors.Join(err, v.forget(ctx, headers.MessageID))
+	}
+
+	return event, nil
+}
+
+// ValidateAndParse verifies a webhook from the values of its headers and decodes its payload.
+func (v *EventVerifier) ValidateAndParse(
+	ctx context.Context,
+	subscriptionName SubscriptionName,
+	version string,
+	eventSignature string,
+	messageID string,
+	timestamp string,
+	body []byte,
+) (interface{}, error) {
+	err := v.verify(ctx, eventSignature, messageID, timestamp, body)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := decodeEvent(subscriptionName.String(), version, body)
+	if err != nil {
+		return nil, errors.Join(err, v.forget(ctx, messageID))
+	}
+
+	return event, nil
+}
+
+func (v *EventVerifier) verifyHeaders(ctx context.Context, headers WebhookHeaders, body []byte) error {
+	return v.verify(ctx, headers.Signature, headers.MessageID, headers.RawTimestamp, body)
+}
+
+func (v *EventVerifier) verify(ctx context.Context, eventSignature, messageID, timestamp string, body []byte) error {
+	if !v.skipSignatureValidation() {
+		publicKey, err := v.publicKey()
+		if err != nil {
+			return err
+		}
+
+		err = verifyEventSignatureWith(ctx, publicKey, eventSignature, messageID, timestamp, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	if v.ReplayGuard != nil {
+		return v.ReplayGuard.check(ctx, messageID, timestamp, v.now())
+	}
+
+	return nil
+}
+
+// forget forgets the message ID of an event which could not be handled, so its retry is not rejected as a replay.
+func (v *EventVerifier) forget(ctx context.Context, messageID string) error {
+	if v.ReplayGuard == nil {
+		return nil
+	}
+
+	return v.ReplayGuard.Forget(ctx, messageID)
+}
+
+func (v *EventVerifier) publicKey() (PublicKeyProvider, error) {
+	switch {
+	case v.PublicKey != nil:
+		return v.PublicKey, nil
+	case v.useGlobals:
+		return defaultEventPublicKeyProvider, nil
+	default:
+		return kickEventPublicKeyProvider()
+	}
+}
+
+func (v *EventVerifier) skipSignatureValidation() bool {
+	return v.SkipSignatureValidation || (v.useGlobals && SkipSignatureValidation)
+}
+
+func (v *EventVerifier) now() time.Time {
+	if v.Now != nil {
+		return v.Now()
+	}
+
+	return v.ReplayGuard.now()
+}
@@ -0,0 +1,172 @@
+package gokick_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSignedWebhookRequest(t *testing.T, webhook kicktest.Webhook) (*http.Request, gokick.PublicKeyProvider) {
+	t.Helper()
+
+	server := kicktest.NewServer()
+	t.Cleanup(server.Close)
+
+	publicKey, err := server.PublicKeyPEM()
+	require.NoError(t, err)
+
+	provider, err := gokick.NewStaticPublicKeyProvider(publicKey)
+	require.NoError(t, err)
+
+	request, err := server.NewWebhookRequest(context.Background(), "/webhook", webhook)
+	require.NoError(t, err)
+
+	return request, provider
+}
+
+func TestEventVerifierError(t *testing.T) {
+	t.Run("nil request", func(t *testing.T) {
+		_, err := (&gokick.EventVerifier{}).ParseRequest(nil)
+		require.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("signed with another key", func(t *testing.T) {
+		request, _ := newSignedWebhookRequest(t, kicktest.Webhook{Subscription: gokick.SubscriptionNameChatMessage, Payload: "{}"})
+
+		_, err := (&gokick.EventVerifier{}).ParseRequest(request)
+		require.EqualError(t, err, "failed to verify event validity: failed to verify signature: crypto/rsa: verification error")
+	})
+
+	t.Run("package-level skip ignored", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		request, _ := newSignedWebhookRequest(t, kicktest.Webhook{Subscription: gokick.SubscriptionNameChatMessage, Payload: "{}"})
+
+		_, err := (&gokick.EventVerifier{}).ParseRequest(request)
+		require.ErrorContains(t, err, "failed to verify event validity")
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		request, provider := newSignedWebhookRequest(t, kicktest.Webhook{
+			Subscription: gokick.SubscriptionNameChatMessage,
+			Timestamp:    webhookTime,
+			Payload:      "{}",
+		})
+
+		verifier := &gokick.EventVerifier{
+			PublicKey:   provider,
+			ReplayGuard: gokick.NewReplayGuard(),
+			Now:         func() time.Time { return webhookTime },
+		}
+
+		require.NoError(t, verifier.Verify(context.Background(), request.Header, []byte(`"{}"`)))
+
+		err := verifier.Verify(context.Background(), request.Header, []byte(`"{}"`))
+		require.ErrorIs(t, err, gokick.ErrEventReplayed)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		verifier := &gokick.EventVerifier{
+			SkipSignatureValidation: true,
+			ReplayGuard:             gokick.NewReplayGuard(),
+			Now:                     func() time.Time { return webhookTime },
+		}
+		headers := gokick.WebhookHeaders{
+			Name:         "channel.followed",
+			Subscription: gokick.SubscriptionNameChannelFollow,
+			Version:      "1",
+			MessageID:    "message ID",
+			RawTimestamp: webhookTime.Format(time.RFC3339),
+		}
+
+		_, err := verifier.VerifyEvent(context.Background(), headers, []byte("invalid"))
+		require.ErrorContains(t, err, "failed to unmarshal")
+
+		_, err = verifier.VerifyEvent(context.Background(), headers, []byte("{}"))
+		require.NoError(t, err, "the message ID of the invalid delivery is forgotten")
+	})
+
+	t.Run("too old", func(t *testing.T) {
+		request, provider := newSignedWebhookRequest(t, kicktest.Webhook{
+			Subscription: gokick.SubscriptionNameChatMessage,
+			Timestamp:    webhookTime,
+			Payload:      "{}",
+		})
+
+		verifier := &gokick.EventVerifier{
+			PublicKey:   provider,
+			ReplayGuard: gokick.NewReplayGuard(),
+			Now:         func() time.Time { return webhookTime.Add(time.Hour) },
+		}
+
+		_, err := verifier.ParseRequest(request)
+		require.ErrorIs(t, err, gokick.ErrEventTooOld)
+	})
+}
+
+func TestEventVerifierSuccess(t *testing.T) {
+	t.Run("parse request", func(t *testing.T) {
+		request, provider := newSignedWebhookRequest(t, kicktest.Webhook{
+			Subscription: gokick.SubscriptionNameChatMessage,
+			Payload:      gokick.ChatMessageEvent{Content: "hello"},
+		})
+
+		event, err := (&gokick.EventVerifier{PublicKey: provider}).ParseRequest(request)
+		require.NoError(t, err)
+		require.IsType(t, &gokick.ChatMessageEvent{}, event)
+		assert.Equal(t, "hello", event.(*gokick.ChatMessageEvent).Content)
+	})
+
+	t.Run("parse request event", func(t *testing.T) {
+		request, provider := newSignedWebhookRequest(t, kicktest.Webhook{
+			Subscription: gokick.SubscriptionNameKicksGifted,
+			Version:      1,
+			MessageID:    "01JMND5PSxxxxxx",
+			Timestamp:    webhookTime,
+			Payload:      json.RawMessage(`{"gift":{"amount":100}}`),
+		})
+
+		event, err := (&gokick.EventVerifier{PublicKey: provider}).ParseRequestEvent(request)
+		require.NoError(t, err)
+		assert.Equal(t, "01JMND5PSxxxxxx", event.ID)
+		assert.Equal(t, gokick.SubscriptionNameKicksGifted, event.Subscription)
+		assert.True(t, event.KnownSubscription)
+		assert.Equal(t, "1", event.Version)
+		assert.True(t, webhookTime.Equal(event.Timestamp))
+		assert.JSONEq(t, `{"gift":{"amount":100}}`, string(event.Raw))
+		require.IsType(t, &gokick.KicksGiftedEvent{}, event.Payload)
+		assert.Equal(t, 100, event.Payload.(*gokick.KicksGiftedEvent).Gift.Amount)
+	})
+
+	t.Run("verify event", func(t *testing.T) {
+		event, err := (&gokick.EventVerifier{SkipSignatureValidation: true}).VerifyEvent(
+			context.Background(),
+			gokick.WebhookHeaders{Subscription: gokick.SubscriptionNameChannelFollow, Version: "1", MessageID: "message ID"},
+			[]byte("{}"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "message ID", event.ID)
+		assert.IsType(t, &gokick.ChannelFollowEvent{}, event.Payload)
+	})
+
+	t.Run("skip signature validation", func(t *testing.T) {
+		event, err := (&gokick.EventVerifier{SkipSignatureValidation: true}).ValidateAndParse(
+			context.Background(),
+			gokick.SubscriptionNameChannelFollow,
+			"1",
+			"invalid signature",
+			"message ID",
+			"2025-02-21T23:23:36Z",
+			[]byte("{}"),
+		)
+		require.NoError(t, err)
+		assert.IsType(t, &gokick.ChannelFollowEvent{}, event)
+	})
+}
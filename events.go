@@ -38,6 +38,7 @@ func (c *Client) GetSubscriptions(ctx context.Context) (EventsResponseWrapper, e
 	response, err := makeRequest[[]EventResponse](
 		ctx,
 		c,
+		Operation{Name: "GetSubscriptions"},
 		http.MethodGet,
 		"/public/v1/events/subscriptions",
 		http.StatusOK,
@@ -97,6 +98,7 @@ func (c *Client) CreateSubscriptions(
 	response, err := makeRequest[[]CreateSubscriptionResponse](
 		ctx,
 		c,
+		Operation{Name: "CreateSubscriptions", Scopes: []Scope{ScopeEventSubscribe}},
 		http.MethodPost,
 		"/public/v1/events/subscriptions",
 		http.StatusOK,
@@ -137,6 +139,7 @@ func (c *Client) DeleteSubscriptions(ctx context.Context, filter SubscriptionToD
 	_, err := makeRequest[EmptyResponse](
 		ctx,
 		c,
+		Operation{Name: "DeleteSubscriptions", Scopes: []Scope{ScopeEventSubscribe}},
 		http.MethodDelete,
 		fmt.Sprintf("/public/v1/events/subscriptions%s", filter.ToQueryString()),
 		http.StatusNoContent,
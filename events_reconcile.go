@@ -0,0 +1,177 @@
+package gokick
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReconcileSubscriptionsOptions configures ReconcileSubscriptions.
+type ReconcileSubscriptionsOptions struct {
+	// DryRun computes the changes without applying them.
+	DryRun bool
+}
+
+// SubscriptionsReconciliation lists the changes made by ReconcileSubscriptions, or to make with DryRun.
+type SubscriptionsReconciliation struct {
+	// Kept are the existing subscriptions matching a desired one.
+	Kept []EventResponse
+	// Created are the subscriptions created, without SubscriptionID with DryRun.
+	Created []CreateSubscriptionResponse
+	// Failed are the subscriptions KICK refused to create, with its Error.
+	Failed []CreateSubscriptionResponse
+	// Deleted are the subscriptions deleted: not desired, duplicated, or of another version than the desired one.
+	Deleted []EventResponse
+}
+
+type subscriptionKey struct {
+	name    string
+	version int
+	method  string
+}
+
+// webhookSubscriptionKey is the key of the webhook subscription ReconcileSubscriptions creates for subscription.
+func webhookSubscriptionKey(subscription SubscriptionRequest) subscriptionKey {
+	return subscriptionKey{
+		name:    subscription.Name.String(),
+		version: subscription.Version,
+		method:  SubscriptionMethodWebhook.String(),
+	}
+}
+
+// ReconcileSubscriptions makes the webhook subscriptions of broadcasterUserID match desired: it creates the missing
+// subscriptions, then deletes the ones that are not desired, including the ones delivered by another method than
+// webhooks. A subscription to another version of a desired event is
+// only deleted once the desired version is created, so no event is missed when the creation fails.
+//
+// On error, the result lists the changes made so far.
+func (c *Client) ReconcileSubscriptions(
+	ctx context.Context,
+	desired []SubscriptionRequest,
+	broadcasterUserID int,
+	options ReconcileSubscriptionsOptions,
+) (SubscriptionsReconciliation, error) {
+	var result SubscriptionsReconciliation
+
+	existing, err := c.GetSubscriptions(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	kept, stale, missing := diffSubscriptions(existing.Result, desired, broadcasterUserID)
+	result.Kept = kept
+
+	failedNames, err := c.createMissingSubscriptions(ctx, missing, broadcasterUserID, options, &result)
+	if err != nil {
+		return result, err
+	}
+
+	var toDelete []EventResponse
+	for _, subscription := range stale {
+		// Keep receiving the events of the previous version until the desired one can be created.
+		if !failedNames[subscription.Event] {
+			toDelete = append(toDelete, subscription)
+		}
+	}
+
+	if len(toDelete) == 0 || options.DryRun {
+		result.Deleted = toDelete
+		return result, nil
+	}
+
+	ids := make([]string, len(toDelete))
+	for i := range toDelete {
+		ids[i] = toDelete[i].ID
+	}
+
+	_, err = c.DeleteSubscriptions(ctx, NewSubscriptionToDeleteFilter().SetIDs(ids))
+	if err != nil {
+		return result, fmt.Errorf("failed to delete subscriptions: %w", err)
+	}
+	result.Deleted = toDelete
+
+	return result, nil
+}
+
+// diffSubscriptions splits the existing subscriptions of broadcasterUserID into the ones matching a desired
+// subscription, kept, and the others, stale, and returns the desired subscriptions missing.
+func diffSubscriptions(
+	existing []EventResponse,
+	desired []SubscriptionRequest,
+	broadcasterUserID int,
+) ([]EventResponse, []EventResponse, []SubscriptionRequest) {
+	wanted := make(map[subscriptionKey]bool, len(desired))
+	for _, subscription := range desired {
+		wanted[webhookSubscriptionKey(subscription)] = true
+	}
+
+	found := make(map[subscriptionKey]bool)
+	var kept, stale []EventResponse
+	for _, subscription := range existing {
+		if subscription.BroadcasterUserID != broadcasterUserID {
+			continue
+		}
+
+		key := subscriptionKey{name: subscription.Event, version: subscription.Version, method: subscription.Method}
+		if wanted[key] && !found[key] {
+			found[key] = true
+			kept = append(kept, subscription)
+		} else {
+			stale = append(stale, subscription)
+		}
+	}
+
+	var missing []SubscriptionRequest
+	for _, subscription := range desired {
+		key := webhookSubscriptionKey(subscription)
+		if !found[key] {
+			found[key] = true
+			missing = append(missing, subscription)
+		}
+	}
+
+	return kept, stale, missing
+}
+
+// createMissingSubscriptions creates missing, records the outcome in result, and returns the names of the events
+// KICK refused to subscribe to.
+func (c *Client) createMissingSubscriptions(
+	ctx context.Context,
+	missing []SubscriptionRequest,
+	broadcasterUserID int,
+	options ReconcileSubscriptionsOptions,
+	result *SubscriptionsReconciliation,
+) (map[string]bool, error) {
+	failedNames := make(map[string]bool)
+
+	if len(missing) == 0 {
+		return failedNames, nil
+	}
+
+	if options.DryRun {
+		for _, subscription := range missing {
+			result.Created = append(result.Created, CreateSubscriptionResponse{
+				Name:    subscription.Name.String(),
+				Version: subscription.Version,
+			})
+		}
+
+		return failedNames, nil
+	}
+
+	created, err := c.CreateSubscriptions(ctx, SubscriptionMethodWebhook, missing, &broadcasterUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscriptions: %w", err)
+	}
+
+	for _, subscription := range created.Result {
+		if subscription.Error != "" {
+			failedNames[subscription.Name] = true
+			result.Failed = append(result.Failed, subscription)
+			continue
+		}
+
+		result.Created = append(result.Created, subscription)
+	}
+
+	return failedNames, nil
+}
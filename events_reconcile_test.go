@@ -0,0 +1,187 @@
+package gokick_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupReconcileServer(t *testing.T) (*kicktest.Server, *gokick.Client) {
+	t.Helper()
+
+	server := kicktest.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := server.NewClient(&gokick.ClientOptions{
+		UserAccessToken: server.IssueUserToken(721956, gokick.ScopeEventSubscribe).AccessToken,
+	})
+	require.NoError(t, err)
+
+	for _, id := range []string{"kept", "duplicate"} {
+		server.AddSubscription(gokick.EventResponse{ID: id, BroadcasterUserID: 721956, Event: "chat.message.sent", Version: 1, Method: "webhook"})
+	}
+	server.AddSubscription(gokick.EventResponse{
+		ID:                "other method",
+		BroadcasterUserID: 721956,
+		Event:             "chat.message.sent",
+		Version:           1,
+		Method:            "websocket",
+	})
+	server.AddSubscription(gokick.EventResponse{ID: "stale", BroadcasterUserID: 721956, Event: "kicks.gifted", Version: 1})
+	server.AddSubscription(gokick.EventResponse{ID: "extra", BroadcasterUserID: 721956, Event: "channel.followed", Version: 1})
+	server.AddSubscription(gokick.EventResponse{ID: "other", BroadcasterUserID: 117, Event: "channel.followed", Version: 1})
+
+	return server, client
+}
+
+func TestReconcileSubscriptionsError(t *testing.T) {
+	desired := []gokick.SubscriptionRequest{
+		{Name: gokick.SubscriptionNameChatMessage, Version: 2},
+		{Name: gokick.SubscriptionNameKicksGifted, Version: 1},
+	}
+
+	t.Run("get subscriptions", func(t *testing.T) {
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"internal server error", "data":null}`)
+		})
+
+		_, err := kickClient.ReconcileSubscriptions(context.Background(), desired, 721956, gokick.ReconcileSubscriptionsOptions{})
+		require.EqualError(t, err, "failed to get subscriptions: Error 500: internal server error")
+	})
+
+	t.Run("create subscriptions", func(t *testing.T) {
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, `{"message":"", "data":[]}`)
+				return
+			}
+
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"forbidden", "data":null}`)
+		})
+
+		_, err := kickClient.ReconcileSubscriptions(context.Background(), desired, 721956, gokick.ReconcileSubscriptionsOptions{})
+		require.EqualError(t, err, "failed to create subscriptions: Error 403: forbidden")
+	})
+
+	t.Run("delete subscriptions", func(t *testing.T) {
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `{"message":"", "data":[
+					{"id":"extra","broadcaster_user_id":721956,"event":"channel.followed","version":1},
+					{"id":"gifted","broadcaster_user_id":721956,"event":"kicks.gifted","version":1}
+				]}`)
+			case http.MethodPost:
+				fmt.Fprint(w, `{"message":"", "data":[{"name":"chat.message.sent","version":2,"subscription_id":"created"}]}`)
+			default:
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"message":"forbidden", "data":null}`)
+			}
+		})
+
+		result, err := kickClient.ReconcileSubscriptions(context.Background(), desired, 721956, gokick.ReconcileSubscriptionsOptions{})
+		require.EqualError(t, err, "failed to delete subscriptions: Error 403: forbidden")
+		require.Len(t, result.Created, 1)
+		assert.Equal(t, "created", result.Created[0].SubscriptionID)
+		assert.Empty(t, result.Deleted)
+	})
+
+	t.Run("subscription refused", func(t *testing.T) {
+		var deleted []string
+
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `{"message":"", "data":[
+					{"id":"previous","broadcaster_user_id":721956,"event":"chat.message.sent","version":1},
+					{"id":"extra","broadcaster_user_id":721956,"event":"channel.followed","version":1}
+				]}`)
+			case http.MethodPost:
+				fmt.Fprint(w, `{"message":"", "data":[
+					{"name":"chat.message.sent","version":2,"error":"invalid version"},
+					{"name":"kicks.gifted","version":1,"subscription_id":"created"}
+				]}`)
+			default:
+				deleted = r.URL.Query()["id"]
+				w.WriteHeader(http.StatusNoContent)
+			}
+		})
+
+		result, err := kickClient.ReconcileSubscriptions(context.Background(), desired, 721956, gokick.ReconcileSubscriptionsOptions{})
+		require.NoError(t, err)
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, "invalid version", result.Failed[0].Error)
+		require.Len(t, result.Created, 1)
+		assert.Equal(t, "kicks.gifted", result.Created[0].Name)
+		assert.Equal(t, []string{"extra"}, deleted, "the previous version is kept until the new one is created")
+	})
+}
+
+func TestReconcileSubscriptionsSuccess(t *testing.T) {
+	desired := []gokick.SubscriptionRequest{
+		{Name: gokick.SubscriptionNameChatMessage, Version: 1},
+		{Name: gokick.SubscriptionNameKicksGifted, Version: 2},
+		{Name: gokick.SubscriptionNameKicksGifted, Version: 2},
+		{Name: gokick.SubscriptionNameModerationBanned, Version: 1},
+	}
+
+	t.Run("reconcile", func(t *testing.T) {
+		server, client := setupReconcileServer(t)
+
+		result, err := client.ReconcileSubscriptions(context.Background(), desired, 721956, gokick.ReconcileSubscriptionsOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, result.Kept, 1)
+		assert.Equal(t, "kept", result.Kept[0].ID)
+		require.Len(t, result.Created, 2)
+		assert.Equal(t, "kicks.gifted", result.Created[0].Name)
+		assert.Equal(t, 2, result.Created[0].Version)
+		assert.NotEmpty(t, result.Created[0].SubscriptionID)
+		assert.Equal(t, "moderation.banned", result.Created[1].Name)
+		assert.Empty(t, result.Failed)
+
+		deleted := make([]string, len(result.Deleted))
+		for i, subscription := range result.Deleted {
+			deleted[i] = subscription.ID
+		}
+		assert.Equal(t, []string{"duplicate", "other method", "stale", "extra"}, deleted)
+
+		var remaining []string
+		for _, subscription := range server.Subscriptions() {
+			remaining = append(remaining, fmt.Sprintf("%d %s v%d", subscription.BroadcasterUserID, subscription.Event, subscription.Version))
+		}
+		assert.Equal(t, []string{
+			"721956 chat.message.sent v1",
+			"117 channel.followed v1",
+			"721956 kicks.gifted v2",
+			"721956 moderation.banned v1",
+		}, remaining)
+
+		result, err = client.ReconcileSubscriptions(context.Background(), desired, 721956, gokick.ReconcileSubscriptionsOptions{})
+		require.NoError(t, err)
+		assert.Len(t, result.Kept, 3)
+		assert.Empty(t, result.Created)
+		assert.Empty(t, result.Deleted)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		server, client := setupReconcileServer(t)
+
+		result, err := client.ReconcileSubscriptions(context.Background(), desired, 721956, gokick.ReconcileSubscriptionsOptions{DryRun: true})
+		require.NoError(t, err)
+
+		assert.Len(t, result.Kept, 1)
+		require.Len(t, result.Created, 2)
+		assert.Empty(t, result.Created[0].SubscriptionID)
+		assert.Len(t, result.Deleted, 4)
+		assert.Len(t, server.Subscriptions(), 6)
+	})
+}
@@ -49,6 +49,7 @@ func (c *Client) GetKicksLeaderboard(ctx context.Context, filter KicksLeaderboar
 	response, err := makeRequest[KicksLeaderboardResponse](
 		ctx,
 		c,
+		Operation{Name: "GetKicksLeaderboard", Scopes: []Scope{ScopeKicksRead}},
 		http.MethodGet,
 		fmt.Sprintf("/public/v1/kicks/leaderboard%s", filter.ToQueryString()),
 		http.StatusOK,
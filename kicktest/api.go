@@ -0,0 +1,496 @@
+package kicktest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scorfly/gokick"
+)
+
+func (s *Server) handleGetCategories(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("q"))
+
+	page := 1
+	if value := r.URL.Query().Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			writeError(w, http.StatusBadRequest, "invalid page")
+			return
+		}
+		page = parsed
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matching := make([]gokick.CategoryResponse, 0, len(s.categories))
+	for _, category := range s.categories {
+		if strings.Contains(strings.ToLower(category.Name), query) {
+			matching = append(matching, category)
+		}
+	}
+
+	start := min((page-1)*s.categoriesPageSize, len(matching))
+	end := min(start+s.categoriesPageSize, len(matching))
+
+	writeData(w, http.StatusOK, matching[start:end])
+}
+
+func (s *Server) handleGetCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid category ID")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.categories, func(c gokick.CategoryResponse) bool { return c.ID == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeData(w, http.StatusOK, s.categories[i])
+}
+
+func (s *Server) handleTokenIntrospect(w http.ResponseWriter, r *http.Request) {
+	token := tokenFromRequest(r)
+
+	tokenType := "user"
+	if token.App {
+		tokenType = "app"
+	}
+
+	s.mu.Lock()
+	clientID := s.clientID
+	s.mu.Unlock()
+
+	writeData(w, http.StatusOK, gokick.TokenIntrospectResponse{
+		Active:    true,
+		ClientID:  clientID,
+		Exp:       int(token.ExpiresAt.Unix()),
+		Scope:     joinScopes(token.Scopes),
+		TokenType: tokenType,
+	})
+}
+
+func (s *Server) handleGetUsers(w http.ResponseWriter, r *http.Request) {
+	ids, err := queryInts(r, "id")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if len(ids) == 0 {
+		ids = []int{tokenFromRequest(r).UserID}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := make([]gokick.UserResponse, 0, len(ids))
+	for _, id := range ids {
+		i := slices.IndexFunc(s.users, func(u gokick.UserResponse) bool { return u.UserID == id })
+		if i >= 0 {
+			users = append(users, s.users[i])
+		}
+	}
+
+	writeData(w, http.StatusOK, users)
+}
+
+func (s *Server) handleGetChannels(w http.ResponseWriter, r *http.Request) {
+	ids, err := queryInts(r, "broadcaster_user_id")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid broadcaster user ID")
+		return
+	}
+
+	slugs := r.URL.Query()["slug"]
+	if len(ids) == 0 && len(slugs) == 0 {
+		ids = []int{tokenFromRequest(r).UserID}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channels := make([]gokick.ChannelResponse, 0, len(ids)+len(slugs))
+	for _, id := range ids {
+		if i := s.channelIndexLocked(id); i >= 0 {
+			channels = append(channels, s.channels[i])
+		}
+	}
+
+	for _, slug := range slugs {
+		i := slices.IndexFunc(s.channels, func(c gokick.ChannelResponse) bool { return c.Slug == slug })
+		if i >= 0 {
+			channels = append(channels, s.channels[i])
+		}
+	}
+
+	writeData(w, http.StatusOK, channels)
+}
+
+func (s *Server) handlePatchChannel(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		StreamTitle *string   `json:"stream_title"`
+		CategoryID  *int      `json:"category_id"`
+		CustomTags  *[]string `json:"custom_tags"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	broadcasterUserID := tokenFromRequest(r).UserID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.channelIndexLocked(broadcasterUserID)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if body.StreamTitle != nil {
+		s.channels[i].StreamTitle = *body.StreamTitle
+	}
+
+	if body.CategoryID != nil {
+		j := slices.IndexFunc(s.categories, func(c gokick.CategoryResponse) bool { return c.ID == *body.CategoryID })
+		if j < 0 {
+			writeError(w, http.StatusBadRequest, "invalid category ID")
+			return
+		}
+		s.channels[i].Category = s.categories[j]
+	}
+
+	if body.CustomTags != nil {
+		s.channelTags[broadcasterUserID] = slices.Clone(*body.CustomTags)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handlePostChat(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		BroadcasterUserID int    `json:"broadcaster_user_id"`
+		Content           string `json:"content"`
+		ReplyToMessageID  string `json:"reply_to_message_id"`
+		Type              string `json:"type"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	messageType, err := gokick.NewMessageType(body.Type)
+	if err != nil || body.Content == "" {
+		writeError(w, http.StatusBadRequest, "invalid message")
+		return
+	}
+
+	token := tokenFromRequest(r)
+	if body.BroadcasterUserID == 0 {
+		if messageType != gokick.MessageTypeBot {
+			writeError(w, http.StatusBadRequest, "broadcaster_user_id is required")
+			return
+		}
+		body.BroadcasterUserID = token.UserID
+	}
+
+	message := ChatMessage{
+		MessageID:         randomID(),
+		BroadcasterUserID: body.BroadcasterUserID,
+		SenderUserID:      token.UserID,
+		Content:           body.Content,
+		ReplyToMessageID:  body.ReplyToMessageID,
+		Type:              body.Type,
+	}
+
+	s.mu.Lock()
+	s.chatMessages = append(s.chatMessages, message)
+	s.mu.Unlock()
+
+	writeData(w, http.StatusOK, gokick.ChatResponse{IsSent: true, MessageID: message.MessageID})
+}
+
+type banBody struct {
+	BroadcasterUserID int    `json:"broadcaster_user_id"`
+	Duration          int    `json:"duration"`
+	Reason            string `json:"reason"`
+	UserID            int    `json:"user_id"`
+}
+
+func (s *Server) handlePostBan(w http.ResponseWriter, r *http.Request) {
+	var body banBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil || body.BroadcasterUserID == 0 || body.UserID == 0 {
+		writeError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	s.mu.Lock()
+	s.bans = slices.DeleteFunc(s.bans, func(b Ban) bool {
+		return b.BroadcasterUserID == body.BroadcasterUserID && b.UserID == body.UserID
+	})
+	s.bans = append(s.bans, Ban{
+		BroadcasterUserID: body.BroadcasterUserID,
+		UserID:            body.UserID,
+		ModeratorUserID:   tokenFromRequest(r).UserID,
+		Duration:          body.Duration,
+		Reason:            body.Reason,
+	})
+	s.mu.Unlock()
+
+	writeData(w, http.StatusOK, gokick.BanUserResponse{})
+}
+
+func (s *Server) handleDeleteBan(w http.ResponseWriter, r *http.Request) {
+	var body banBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil || body.BroadcasterUserID == 0 || body.UserID == 0 {
+		writeError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	s.mu.Lock()
+	s.bans = slices.DeleteFunc(s.bans, func(b Ban) bool {
+		return b.BroadcasterUserID == body.BroadcasterUserID && b.UserID == body.UserID
+	})
+	s.mu.Unlock()
+
+	writeData(w, http.StatusOK, gokick.BanUserResponse{})
+}
+
+// livestreamQuery is the filter, sort and limit of a GET /public/v1/livestreams request.
+type livestreamQuery struct {
+	broadcasterUserIDs []int
+	categoryIDs        []int
+	language           string
+	sort               string
+	limit              int
+}
+
+func parseLivestreamQuery(r *http.Request) (livestreamQuery, error) {
+	query := livestreamQuery{
+		language: r.URL.Query().Get("language"),
+		sort:     r.URL.Query().Get("sort"),
+		limit:    25,
+	}
+
+	var err error
+	query.broadcasterUserIDs, err = queryInts(r, "broadcaster_user_id")
+	if err != nil {
+		return livestreamQuery{}, errors.New("invalid broadcaster user ID")
+	}
+
+	query.categoryIDs, err = queryInts(r, "category_id")
+	if err != nil {
+		return livestreamQuery{}, errors.New("invalid category ID")
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		query.limit, err = strconv.Atoi(value)
+		if err != nil || query.limit < 1 || query.limit > 100 {
+			return livestreamQuery{}, errors.New("invalid limit")
+		}
+	}
+
+	return query, nil
+}
+
+func (q livestreamQuery) matches(livestream gokick.LivestreamResponse) bool {
+	return (len(q.broadcasterUserIDs) == 0 || slices.Contains(q.broadcasterUserIDs, livestream.BroadcasterUserID)) &&
+		(len(q.categoryIDs) == 0 || slices.Contains(q.categoryIDs, livestream.Category.ID)) &&
+		(q.language == "" || q.language == livestream.Language)
+}
+
+func (q livestreamQuery) sortLivestreams(livestreams []gokick.LivestreamResponse) {
+	switch q.sort {
+	case gokick.LivestreamSortViewerCount.String():
+		slices.SortStableFunc(livestreams, func(a, b gokick.LivestreamResponse) int { return b.ViewerCount - a.ViewerCount })
+	case gokick.LivestreamSortStartedAt.String():
+		slices.SortStableFunc(livestreams, func(a, b gokick.LivestreamResponse) int { return strings.Compare(b.StartedAt, a.StartedAt) })
+	}
+}
+
+func (s *Server) handleGetLivestreams(w http.ResponseWriter, r *http.Request) {
+	query, err := parseLivestreamQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	livestreams := make([]gokick.LivestreamResponse, 0, len(s.livestreams))
+	for _, livestream := range s.livestreams {
+		if query.matches(livestream) {
+			livestreams = append(livestreams, livestream)
+		}
+	}
+	s.mu.Unlock()
+
+	query.sortLivestreams(livestreams)
+
+	writeData(w, http.StatusOK, livestreams[:min(query.limit, len(livestreams))])
+}
+
+func (s *Server) handleGetLivestreamsStats(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	stats := s.livestreamsStats
+	s.mu.Unlock()
+
+	writeData(w, http.StatusOK, stats)
+}
+
+func (s *Server) handleGetSubscriptions(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, http.StatusOK, s.Subscriptions())
+}
+
+func (s *Server) handlePostSubscriptions(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Method string `json:"method"`
+		Events []struct {
+			Name    string `json:"name"`
+			Version int    `json:"version"`
+		} `json:"events"`
+		BroadcasterUserID int `json:"broadcaster_user_id"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	_, err = gokick.NewSubscriptionMethod(body.Method)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid method")
+		return
+	}
+
+	if body.BroadcasterUserID == 0 {
+		body.BroadcasterUserID = tokenFromRequest(r).UserID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	responses := make([]gokick.CreateSubscriptionResponse, 0, len(body.Events))
+	for _, event := range body.Events {
+		_, err = gokick.NewSubscriptionName(event.Name)
+		if err != nil {
+			responses = append(responses, gokick.CreateSubscriptionResponse{
+				Error:   "invalid event name",
+				Name:    event.Name,
+				Version: event.Version,
+			})
+			continue
+		}
+
+		subscription := gokick.EventResponse{
+			AppID:             s.clientID,
+			BroadcasterUserID: body.BroadcasterUserID,
+			CreatedAt:         now,
+			Event:             event.Name,
+			ID:                randomID(),
+			Method:            body.Method,
+			UpdatedAt:         now,
+			Version:           event.Version,
+		}
+		s.subscriptions = append(s.subscriptions, subscription)
+
+		responses = append(responses, gokick.CreateSubscriptionResponse{
+			Name:           event.Name,
+			SubscriptionID: subscription.ID,
+			Version:        event.Version,
+		})
+	}
+
+	writeData(w, http.StatusOK, responses)
+}
+
+func (s *Server) handleDeleteSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ids := r.URL.Query()["id"]
+	if len(ids) == 0 {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	s.mu.Lock()
+	s.subscriptions = slices.DeleteFunc(s.subscriptions, func(e gokick.EventResponse) bool {
+		return slices.Contains(ids, e.ID)
+	})
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetKicksLeaderboard(w http.ResponseWriter, r *http.Request) {
+	top := 10
+	if value := r.URL.Query().Get("top"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 100 {
+			writeError(w, http.StatusBadRequest, "invalid top")
+			return
+		}
+		top = parsed
+	}
+
+	s.mu.Lock()
+	leaderboard := s.leaderboards[tokenFromRequest(r).UserID]
+	s.mu.Unlock()
+
+	writeData(w, http.StatusOK, gokick.KicksLeaderboardResponse{
+		Lifetime: topEntries(leaderboard.Lifetime, top),
+		Month:    topEntries(leaderboard.Month, top),
+		Week:     topEntries(leaderboard.Week, top),
+	})
+}
+
+func (s *Server) handleGetPublicKey(w http.ResponseWriter, _ *http.Request) {
+	publicKey, err := s.PublicKeyPEM()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeData(w, http.StatusOK, gokick.PublicKeyResponse{PublicKey: publicKey})
+}
+
+func topEntries(entries []gokick.KicksLeaderboardEntry, top int) []gokick.KicksLeaderboardEntry {
+	if entries == nil {
+		return []gokick.KicksLeaderboardEntry{}
+	}
+
+	return slices.Clone(entries[:min(top, len(entries))])
+}
+
+func queryInts(r *http.Request, key string) ([]int, error) {
+	values := r.URL.Query()[key]
+
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, i)
+	}
+
+	return ints, nil
+}
@@ -0,0 +1,98 @@
+package kicktest
+
+import (
+	"slices"
+
+	"github.com/scorfly/gokick"
+)
+
+func (s *Server) AddUser(user gokick.UserResponse) {
+	s.mu.Lock()
+	s.users = append(s.users, user)
+	s.mu.Unlock()
+}
+
+func (s *Server) AddChannel(channel gokick.ChannelResponse) {
+	s.mu.Lock()
+	s.channels = append(s.channels, channel)
+	s.mu.Unlock()
+}
+
+func (s *Server) AddCategory(category gokick.CategoryResponse) {
+	s.mu.Lock()
+	s.categories = append(s.categories, category)
+	s.mu.Unlock()
+}
+
+func (s *Server) AddLivestream(livestream gokick.LivestreamResponse) {
+	s.mu.Lock()
+	s.livestreams = append(s.livestreams, livestream)
+	s.mu.Unlock()
+}
+
+func (s *Server) SetLivestreamsStats(stats gokick.LivestreamStatsResponse) {
+	s.mu.Lock()
+	s.livestreamsStats = stats
+	s.mu.Unlock()
+}
+
+// SetKicksLeaderboard sets the leaderboard returned to the tokens of broadcasterUserID.
+func (s *Server) SetKicksLeaderboard(broadcasterUserID int, leaderboard gokick.KicksLeaderboardResponse) {
+	s.mu.Lock()
+	s.leaderboards[broadcasterUserID] = leaderboard
+	s.mu.Unlock()
+}
+
+func (s *Server) AddSubscription(subscription gokick.EventResponse) {
+	s.mu.Lock()
+	s.subscriptions = append(s.subscriptions, subscription)
+	s.mu.Unlock()
+}
+
+func (s *Server) Channel(broadcasterUserID int) (gokick.ChannelResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.channelIndexLocked(broadcasterUserID)
+	if i < 0 {
+		return gokick.ChannelResponse{}, false
+	}
+
+	return s.channels[i], true
+}
+
+func (s *Server) ChannelTags(broadcasterUserID int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.channelTags[broadcasterUserID])
+}
+
+// ChatMessages returns the messages sent through the chat endpoint, in order.
+func (s *Server) ChatMessages() []ChatMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.chatMessages)
+}
+
+// Bans returns the bans currently in place.
+func (s *Server) Bans() []Ban {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.bans)
+}
+
+func (s *Server) Subscriptions() []gokick.EventResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.subscriptions)
+}
+
+func (s *Server) channelIndexLocked(broadcasterUserID int) int {
+	return slices.IndexFunc(s.channels, func(c gokick.ChannelResponse) bool {
+		return c.BroadcasterUserID == broadcasterUserID
+	})
+}
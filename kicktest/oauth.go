@@ -0,0 +1,240 @@
+package kicktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/scorfly/gokick"
+)
+
+type authorizationCode struct {
+	userID      int
+	scopes      []gokick.Scope
+	redirectURI string
+}
+
+// IssueUserToken creates a user access token, with its refresh token, for userID.
+func (s *Server) IssueUserToken(userID int, scopes ...gokick.Scope) gokick.TokenResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issueUserTokenLocked(userID, scopes)
+}
+
+// IssueAppToken creates an app access token, as the client_credentials grant does.
+func (s *Server) IssueAppToken() gokick.AppTokenResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issueAppTokenLocked()
+}
+
+// IssueAuthorizationCode creates a code that the authorization_code grant exchanges for a token of userID.
+func (s *Server) IssueAuthorizationCode(userID int, redirectURI string, scopes ...gokick.Scope) string {
+	code := randomID()
+
+	s.mu.Lock()
+	s.authorizationCodes[code] = authorizationCode{userID: userID, scopes: scopes, redirectURI: redirectURI}
+	s.mu.Unlock()
+
+	return code
+}
+
+// SetAuthorizeUser sets the user that /oauth/authorize logs in, without any consent screen.
+func (s *Server) SetAuthorizeUser(userID int) {
+	s.mu.Lock()
+	s.authorizeUserID = userID
+	s.mu.Unlock()
+}
+
+// ExpireToken makes accessToken expired, so that the next request using it gets a 401.
+func (s *Server) ExpireToken(accessToken string) {
+	s.mu.Lock()
+	if token, ok := s.tokens[accessToken]; ok {
+		token.ExpiresAt = time.Now().Add(-time.Second)
+	}
+	s.mu.Unlock()
+}
+
+// Token returns the state of an access token.
+func (s *Server) Token(accessToken string) (Token, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[accessToken]
+	if !ok {
+		return Token{}, false
+	}
+
+	return *token, true
+}
+
+func (s *Server) issueUserTokenLocked(userID int, scopes []gokick.Scope) gokick.TokenResponse {
+	token := &Token{
+		AccessToken:  randomID(),
+		RefreshToken: randomID(),
+		UserID:       userID,
+		Scopes:       scopes,
+		ExpiresAt:    time.Now().Add(s.tokenLifetime),
+	}
+
+	s.tokens[token.AccessToken] = token
+	s.refreshTokens[token.RefreshToken] = token
+
+	return gokick.TokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.tokenLifetime.Seconds()),
+		Scope:        joinScopes(scopes),
+		RefreshToken: token.RefreshToken,
+	}
+}
+
+func (s *Server) issueAppTokenLocked() gokick.AppTokenResponse {
+	token := &Token{
+		AccessToken: randomID(),
+		ExpiresAt:   time.Now().Add(s.tokenLifetime),
+		App:         true,
+	}
+
+	s.tokens[token.AccessToken] = token
+
+	return gokick.AppTokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.tokenLifetime.Seconds()),
+	}
+}
+
+func joinScopes(scopes []gokick.Scope) string {
+	values := make([]string, len(scopes))
+	for i := range scopes {
+		values[i] = scopes[i].String()
+	}
+
+	return strings.Join(values, " ")
+}
+
+func parseScopes(value string) []gokick.Scope {
+	var scopes []gokick.Scope
+	for _, name := range strings.Fields(value) {
+		s, err := gokick.NewScope(name)
+		if err == nil {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return scopes
+}
+
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || query.Get("redirect_uri") == "" {
+		writeAuthError(w, http.StatusBadRequest, "invalid_request", "invalid redirect_uri")
+		return
+	}
+
+	s.mu.Lock()
+	userID := s.authorizeUserID
+	s.mu.Unlock()
+
+	code := s.IssueAuthorizationCode(userID, query.Get("redirect_uri"), parseScopes(query.Get("scope"))...)
+
+	values := redirectURI.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirectURI.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		writeAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.validClientLocked(r.PostForm) {
+		writeAuthError(w, http.StatusUnauthorized, "invalid_client", "invalid client credentials")
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code, ok := s.authorizationCodes[r.PostForm.Get("code")]
+		if !ok || code.redirectURI != r.PostForm.Get("redirect_uri") {
+			writeAuthError(w, http.StatusBadRequest, "invalid_grant", "invalid authorization code")
+			return
+		}
+
+		delete(s.authorizationCodes, r.PostForm.Get("code"))
+		writeJSON(w, http.StatusOK, s.issueUserTokenLocked(code.userID, code.scopes))
+	case "client_credentials":
+		writeJSON(w, http.StatusOK, s.issueAppTokenLocked())
+	case "refresh_token":
+		previous, ok := s.refreshTokens[r.PostForm.Get("refresh_token")]
+		if !ok || previous.Revoked {
+			writeAuthError(w, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+			return
+		}
+
+		// Refresh tokens are rotated: the previous pair cannot be used anymore.
+		previous.Revoked = true
+		delete(s.refreshTokens, previous.RefreshToken)
+		writeJSON(w, http.StatusOK, s.issueUserTokenLocked(previous.UserID, previous.Scopes))
+	default:
+		writeAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "unsupported grant type")
+	}
+}
+
+func (s *Server) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		writeAuthError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	value := r.PostForm.Get("token")
+
+	s.mu.Lock()
+	if token, ok := s.tokens[value]; ok {
+		token.Revoked = true
+	}
+	if token, ok := s.refreshTokens[value]; ok {
+		token.Revoked = true
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) validClientLocked(form url.Values) bool {
+	if s.clientID == "" && s.clientSecret == "" {
+		return true
+	}
+
+	return form.Get("client_id") == s.clientID && form.Get("client_secret") == s.clientSecret
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func writeAuthError(w http.ResponseWriter, statusCode int, code, description string) {
+	writeJSON(w, statusCode, struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{Error: code, ErrorDescription: description})
+}
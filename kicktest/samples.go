@@ -0,0 +1,126 @@
+package kicktest
+
+import (
+	"time"
+
+	"github.com/scorfly/gokick"
+)
+
+var (
+	sampleBroadcaster = gokick.UserEvent{
+		UserID:         721956,
+		Username:       "Scorfly",
+		IsVerified:     true,
+		ProfilePicture: "https://files.kick.com/images/user/721956/profile_image/default.webp",
+		ChannelSlug:    "scorfly",
+	}
+	sampleViewer = gokick.UserEvent{
+		UserID:         117,
+		Username:       "viewer",
+		ProfilePicture: "https://files.kick.com/images/user/117/profile_image/default.webp",
+		ChannelSlug:    "viewer",
+		Identity: gokick.IdentityEvent{
+			UsernameColor: "#FF5733",
+			Badges:        []gokick.Badge{{Text: "Subscriber", Type: "subscriber", Count: 3}},
+		},
+	}
+	sampleModerator = gokick.UserEvent{
+		UserID:         118,
+		Username:       "moderator",
+		ProfilePicture: "https://files.kick.com/images/user/118/profile_image/default.webp",
+		ChannelSlug:    "moderator",
+	}
+)
+
+// SamplePayload returns a realistic payload of the events of subscription, as KICK sends them in version 1.
+func SamplePayload(subscription gokick.SubscriptionName) any {
+	now := time.Now().UTC()
+	createdAt := now.Format(time.RFC3339)
+	expiresAt := now.AddDate(0, 1, 0).Format(time.RFC3339)
+
+	switch subscription {
+	case gokick.SubscriptionNameChatMessage:
+		event := &gokick.ChatMessageEvent{
+			MessageID:   randomID(),
+			Broadcaster: sampleBroadcaster,
+			Sender:      sampleViewer,
+			Content:     "Hello [emote:37226:KEKW]",
+			Emotes:      []gokick.ChatMessageEmotesEvent{{EmoteID: 37226}},
+			CreatedAt:   createdAt,
+		}
+		event.Emotes[0].Positions = append(event.Emotes[0].Positions, struct {
+			Start int `json:"s"`
+			End   int `json:"e"`
+		}{Start: 6, End: 23})
+
+		return event
+	case gokick.SubscriptionNameChannelFollow:
+		return &gokick.ChannelFollowEvent{Broadcaster: sampleBroadcaster, Follower: sampleViewer}
+	case gokick.SubscriptionNameChannelSubscriptionRenewal:
+		return &gokick.ChannelSubscriptionRenewalEvent{
+			Broadcaster: sampleBroadcaster,
+			Subscriber:  sampleViewer,
+			Duration:    3,
+			CreatedAt:   createdAt,
+			ExpiresAt:   expiresAt,
+		}
+	case gokick.SubscriptionNameChannelSubscriptionGifts:
+		return &gokick.ChannelSubscriptionGiftsEvent{
+			Broadcaster: sampleBroadcaster,
+			Gifter:      sampleViewer,
+			Giftees:     []gokick.UserEvent{sampleModerator},
+			CreatedAt:   createdAt,
+			ExpiresAt:   expiresAt,
+		}
+	case gokick.SubscriptionNameChannelSubscriptionCreated:
+		return &gokick.ChannelSubscriptionCreatedEvent{
+			Broadcaster: sampleBroadcaster,
+			Subscriber:  sampleViewer,
+			Duration:    1,
+			CreatedAt:   createdAt,
+			ExpiresAt:   expiresAt,
+		}
+	case gokick.SubscriptionNameLivestreamStatusUpdated:
+		return &gokick.LivestreamStatusUpdatedEvent{
+			Broadcaster: sampleBroadcaster,
+			IsLive:      true,
+			Title:       "Playing with the API",
+			StartedAt:   createdAt,
+		}
+	case gokick.SubscriptionNameLivestreamMetadataUpdated:
+		event := &gokick.LivestreamMetadataUpdatedEvent{Broadcaster: sampleBroadcaster}
+		event.Metadata.Title = "Playing with the API"
+		event.Metadata.Language = "en"
+		event.Metadata.Category.ID = "15"
+		event.Metadata.Category.Name = "Just Chatting"
+		event.Metadata.Category.Thumbnail = "https://files.kick.com/images/subcategories/15/banner/default.webp"
+
+		return event
+	case gokick.SubscriptionNameModerationBanned:
+		event := &gokick.ModerationBannedEvent{
+			Broadcaster: sampleBroadcaster,
+			Moderator:   sampleModerator,
+			BannedUser:  sampleViewer,
+		}
+		event.Metadata.Reason = "spam"
+		event.Metadata.CreatedAt = createdAt
+		event.Metadata.ExpiresAt = now.Add(10 * time.Minute).Format(time.RFC3339)
+
+		return event
+	case gokick.SubscriptionNameKicksGifted:
+		event := &gokick.KicksGiftedEvent{
+			Broadcaster: sampleBroadcaster,
+			Sender:      sampleViewer,
+			CreatedAt:   createdAt,
+		}
+		event.Gift.Amount = 100
+		event.Gift.Name = "Full Send"
+		event.Gift.Type = "BASIC"
+		event.Gift.Tier = "BASIC"
+		event.Gift.Message = "GG"
+
+		return event
+	}
+
+	return nil
+}
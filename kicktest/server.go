@@ -0,0 +1,248 @@
+// Package kicktest provides an in-memory fake of the KICK API, to be used in integration tests.
+//
+// A Server serves the /public/v1 endpoints and the id.kick.com OAuth endpoints from a single
+// httptest.Server. It is seeded with fixtures, records every write it receives so tests can
+// inspect it afterwards, and can deliver signed webhooks with its own key pair.
+package kicktest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scorfly/gokick"
+)
+
+const (
+	defaultCategoriesPageSize = 100
+	defaultTokenLifetime      = 2 * time.Hour
+)
+
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mu                 sync.Mutex
+	clientID           string
+	clientSecret       string
+	categoriesPageSize int
+	tokenLifetime      time.Duration
+
+	users            []gokick.UserResponse
+	channels         []gokick.ChannelResponse
+	channelTags      map[int][]string
+	categories       []gokick.CategoryResponse
+	livestreams      []gokick.LivestreamResponse
+	livestreamsStats gokick.LivestreamStatsResponse
+	leaderboards     map[int]gokick.KicksLeaderboardResponse
+	chatMessages     []ChatMessage
+	bans             []Ban
+	subscriptions    []gokick.EventResponse
+
+	tokens             map[string]*Token
+	refreshTokens      map[string]*Token
+	authorizationCodes map[string]authorizationCode
+	authorizeUserID    int
+
+	webhookURL string
+	keyOnce    sync.Once
+	privateKey *rsa.PrivateKey
+	keyErr     error
+}
+
+// Token is an access token known by the Server.
+// App tokens have no user.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	UserID       int
+	Scopes       []gokick.Scope
+	ExpiresAt    time.Time
+	App          bool
+	Revoked      bool
+}
+
+type ChatMessage struct {
+	MessageID         string
+	BroadcasterUserID int
+	SenderUserID      int
+	Content           string
+	ReplyToMessageID  string
+	Type              string
+}
+
+type Ban struct {
+	BroadcasterUserID int
+	UserID            int
+	ModeratorUserID   int
+	Duration          int
+	Reason            string
+}
+
+func NewServer() *Server {
+	s := &Server{
+		categoriesPageSize: defaultCategoriesPageSize,
+		tokenLifetime:      defaultTokenLifetime,
+		channelTags:        make(map[int][]string),
+		leaderboards:       make(map[int]gokick.KicksLeaderboardResponse),
+		tokens:             make(map[string]*Token),
+		refreshTokens:      make(map[string]*Token),
+		authorizationCodes: make(map[string]authorizationCode),
+	}
+
+	s.server = httptest.NewServer(s.routes())
+	s.URL = s.server.URL
+
+	return s
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// NewClient returns a gokick.Client talking to the Server. The base URLs of options are overridden.
+func (s *Server) NewClient(options *gokick.ClientOptions) (*gokick.Client, error) {
+	if options == nil {
+		options = &gokick.ClientOptions{}
+	}
+
+	options.APIBaseURL = s.URL
+	options.AuthBaseURL = s.URL
+
+	return gokick.NewClient(options)
+}
+
+// SetClientCredentials makes the OAuth endpoints require this client ID and secret.
+// By default any credentials are accepted.
+func (s *Server) SetClientCredentials(clientID, clientSecret string) {
+	s.mu.Lock()
+	s.clientID = clientID
+	s.clientSecret = clientSecret
+	s.mu.Unlock()
+}
+
+func (s *Server) SetCategoriesPageSize(size int) {
+	s.mu.Lock()
+	s.categoriesPageSize = size
+	s.mu.Unlock()
+}
+
+func (s *Server) SetTokenLifetime(lifetime time.Duration) {
+	s.mu.Lock()
+	s.tokenLifetime = lifetime
+	s.mu.Unlock()
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /oauth/authorize", s.handleAuthorize)
+	mux.HandleFunc("POST /oauth/token", s.handleToken)
+	mux.HandleFunc("POST /oauth/revoke", s.handleRevoke)
+
+	mux.HandleFunc("GET /public/v1/categories", s.authenticated(nil, s.handleGetCategories))
+	mux.HandleFunc("GET /public/v1/categories/{id}", s.authenticated(nil, s.handleGetCategory))
+	mux.HandleFunc("POST /public/v1/token/introspect", s.authenticated(nil, s.handleTokenIntrospect))
+	mux.HandleFunc("GET /public/v1/users", s.authenticated(scope(gokick.ScopeUserRead), s.handleGetUsers))
+	mux.HandleFunc("GET /public/v1/channels", s.authenticated(scope(gokick.ScopeChannelRead), s.handleGetChannels))
+	mux.HandleFunc("PATCH /public/v1/channels", s.authenticated(scope(gokick.ScopeChannelWrite), s.handlePatchChannel))
+	mux.HandleFunc("POST /public/v1/chat", s.authenticated(scope(gokick.ScopeChatWrite), s.handlePostChat))
+	mux.HandleFunc("POST /public/v1/moderation/bans", s.authenticated(scope(gokick.ScopeModerationBan), s.handlePostBan))
+	mux.HandleFunc("DELETE /public/v1/moderation/bans", s.authenticated(scope(gokick.ScopeModerationBan), s.handleDeleteBan))
+	mux.HandleFunc("GET /public/v1/livestreams", s.authenticated(nil, s.handleGetLivestreams))
+	mux.HandleFunc("GET /public/v1/livestreams/stats", s.authenticated(nil, s.handleGetLivestreamsStats))
+	mux.HandleFunc("GET /public/v1/events/subscriptions", s.authenticated(nil, s.handleGetSubscriptions))
+	mux.HandleFunc("POST /public/v1/events/subscriptions", s.authenticated(scope(gokick.ScopeEventSubscribe), s.handlePostSubscriptions))
+	mux.HandleFunc("DELETE /public/v1/events/subscriptions", s.authenticated(scope(gokick.ScopeEventSubscribe), s.handleDeleteSubscriptions))
+	mux.HandleFunc("GET /public/v1/kicks/leaderboard", s.authenticated(scope(gokick.ScopeKicksRead), s.handleGetKicksLeaderboard))
+	mux.HandleFunc("GET /public/v1/public-key", s.handleGetPublicKey)
+
+	return mux
+}
+
+type tokenContextKey struct{}
+
+func scope(s gokick.Scope) *gokick.Scope {
+	return &s
+}
+
+// authenticated rejects requests without a valid bearer token, and user tokens missing the required scope.
+func (s *Server) authenticated(required *gokick.Scope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		s.mu.Lock()
+		token, ok := s.tokens[accessToken]
+		var snapshot Token
+		if ok {
+			snapshot = *token
+		}
+		s.mu.Unlock()
+
+		if !ok || snapshot.Revoked || time.Now().After(snapshot.ExpiresAt) {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		if required != nil && !snapshot.App && !hasScope(snapshot.Scopes, *required) {
+			writeError(w, http.StatusForbidden, "Forbidden")
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), tokenContextKey{}, snapshot)))
+	}
+}
+
+func tokenFromRequest(r *http.Request) Token {
+	token, _ := r.Context().Value(tokenContextKey{}).(Token)
+	return token
+}
+
+func hasScope(scopes []gokick.Scope, required gokick.Scope) bool {
+	for _, s := range scopes {
+		if s == required {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeData(w http.ResponseWriter, statusCode int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(struct {
+		Data    any    `json:"data"`
+		Message string `json:"message"`
+	}{Data: data, Message: "OK"})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(struct {
+		Data    any    `json:"data"`
+		Message string `json:"message"`
+	}{Message: message})
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
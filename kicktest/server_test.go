@@ -0,0 +1,412 @@
+package kicktest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupServer(t *testing.T) *kicktest.Server {
+	t.Helper()
+
+	server := kicktest.NewServer()
+	t.Cleanup(server.Close)
+
+	server.AddUser(gokick.UserResponse{UserID: 721956, Name: "Scorfly", Email: "scorfly@example.com"})
+	server.AddUser(gokick.UserResponse{UserID: 117, Name: "Viewer"})
+	server.AddCategory(gokick.CategoryResponse{ID: 1, Name: "Just Chatting"})
+	server.AddCategory(gokick.CategoryResponse{ID: 2, Name: "Fortnite"})
+	server.AddCategory(gokick.CategoryResponse{ID: 3, Name: "Fall Guys"})
+	server.AddChannel(gokick.ChannelResponse{BroadcasterUserID: 721956, Slug: "scorfly", StreamTitle: "title"})
+
+	return server
+}
+
+func setupClient(t *testing.T, server *kicktest.Server, scopes ...gokick.Scope) *gokick.Client {
+	t.Helper()
+
+	token := server.IssueUserToken(721956, scopes...)
+
+	client, err := server.NewClient(&gokick.ClientOptions{
+		UserAccessToken:  token.AccessToken,
+		UserRefreshToken: token.RefreshToken,
+		ClientID:         "client-id",
+		ClientSecret:     "client-secret",
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestServerAuthenticationError(t *testing.T) {
+	t.Run("unknown token", func(t *testing.T) {
+		server := setupServer(t)
+
+		client, err := server.NewClient(&gokick.ClientOptions{UserAccessToken: "unknown"})
+		require.NoError(t, err)
+
+		_, err = client.GetCategory(context.Background(), 1)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusUnauthorized, kickError.Code())
+	})
+
+	t.Run("missing scope", func(t *testing.T) {
+		server := setupServer(t)
+		client := setupClient(t, server, gokick.ScopeUserRead)
+
+		_, err := client.SendChatMessage(context.Background(), nil, "message", nil, gokick.MessageTypeBot)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusForbidden, kickError.Code())
+		assert.Empty(t, server.ChatMessages())
+	})
+
+	t.Run("invalid client credentials", func(t *testing.T) {
+		server := setupServer(t)
+		server.SetClientCredentials("client-id", "client-secret")
+
+		client, err := server.NewClient(&gokick.ClientOptions{ClientID: "client-id", ClientSecret: "wrong"})
+		require.NoError(t, err)
+
+		_, err = client.GetAppAccessToken(context.Background())
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusUnauthorized, kickError.Code())
+		assert.Equal(t, "invalid_client", kickError.Message())
+	})
+}
+
+func TestServerAuthenticationSuccess(t *testing.T) {
+	t.Run("authorization code", func(t *testing.T) {
+		server := setupServer(t)
+		code := server.IssueAuthorizationCode(721956, "http://localhost/callback", gokick.ScopeUserRead)
+
+		client, err := server.NewClient(&gokick.ClientOptions{ClientID: "client-id", ClientSecret: "client-secret"})
+		require.NoError(t, err)
+
+		token, err := client.GetToken(context.Background(), "http://localhost/callback", code, "verifier")
+		require.NoError(t, err)
+		assert.Equal(t, "user:read", token.Scope)
+
+		client.SetUserAccessToken(token.AccessToken)
+
+		users, err := client.GetUsers(context.Background(), gokick.NewUserListFilter())
+		require.NoError(t, err)
+		require.Len(t, users.Result, 1)
+		assert.Equal(t, "Scorfly", users.Result[0].Name)
+	})
+
+	t.Run("authorize redirects with a code", func(t *testing.T) {
+		server := setupServer(t)
+		server.SetAuthorizeUser(721956)
+
+		client, err := server.NewClient(&gokick.ClientOptions{ClientID: "client-id"})
+		require.NoError(t, err)
+
+		authorizeURL, err := client.GetAuthorize("http://localhost/callback", "state", "challenge", []gokick.Scope{gokick.ScopeUserRead})
+		require.NoError(t, err)
+
+		httpClient := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		response, err := httpClient.Get(authorizeURL)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		require.Equal(t, http.StatusFound, response.StatusCode)
+
+		location, err := response.Location()
+		require.NoError(t, err)
+		assert.Equal(t, "state", location.Query().Get("state"))
+		assert.NotEmpty(t, location.Query().Get("code"))
+	})
+
+	t.Run("refresh after expiry rotates tokens", func(t *testing.T) {
+		server := setupServer(t)
+		token := server.IssueUserToken(721956, gokick.ScopeUserRead)
+
+		client, err := server.NewClient(&gokick.ClientOptions{
+			UserAccessToken:  token.AccessToken,
+			UserRefreshToken: token.RefreshToken,
+			ClientID:         "client-id",
+			ClientSecret:     "client-secret",
+		})
+		require.NoError(t, err)
+
+		refreshed := make(chan string, 1)
+		client.OnUserAccessTokenRefreshed(func(accessToken, _ string) { refreshed <- accessToken })
+
+		server.ExpireToken(token.AccessToken)
+
+		_, err = client.GetUsers(context.Background(), gokick.NewUserListFilter())
+		require.NoError(t, err)
+
+		newToken, ok := server.Token(<-refreshed)
+		require.True(t, ok)
+		assert.Equal(t, 721956, newToken.UserID)
+
+		previous, ok := server.Token(token.AccessToken)
+		require.True(t, ok)
+		assert.True(t, previous.Revoked)
+	})
+
+	t.Run("app token and revoke", func(t *testing.T) {
+		server := setupServer(t)
+
+		client, err := server.NewClient(&gokick.ClientOptions{ClientID: "client-id", ClientSecret: "client-secret"})
+		require.NoError(t, err)
+
+		token, err := client.GetAppAccessToken(context.Background())
+		require.NoError(t, err)
+
+		client.SetAppAccessToken(token.AccessToken)
+
+		introspect, err := client.TokenIntrospect(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "app", introspect.Result.TokenType)
+
+		require.NoError(t, client.RevokeToken(context.Background(), gokick.TokenTypeAccess, token.AccessToken))
+
+		_, err = client.GetCategory(context.Background(), 1)
+		require.Error(t, err)
+	})
+}
+
+func TestServerAPI(t *testing.T) {
+	server := setupServer(t)
+	client := setupClient(t, server,
+		gokick.ScopeUserRead,
+		gokick.ScopeChannelRead,
+		gokick.ScopeChannelWrite,
+		gokick.ScopeChatWrite,
+		gokick.ScopeModerationBan,
+		gokick.ScopeEventSubscribe,
+		gokick.ScopeKicksRead,
+	)
+	ctx := context.Background()
+
+	t.Run("categories", func(t *testing.T) {
+		server.SetCategoriesPageSize(1)
+
+		categories, err := client.GetCategories(ctx, gokick.NewCategoryListFilter().SetQuery("f").SetPage(2))
+		require.NoError(t, err)
+		require.Len(t, categories.Result, 1)
+		assert.Equal(t, "Fall Guys", categories.Result[0].Name)
+
+		category, err := client.GetCategory(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "Fortnite", category.Result.Name)
+
+		_, err = client.GetCategory(ctx, 404)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusNotFound, kickError.Code())
+	})
+
+	t.Run("channels", func(t *testing.T) {
+		_, err := client.UpdateStreamTitle(ctx, "new title")
+		require.NoError(t, err)
+
+		_, err = client.UpdateStreamCategory(ctx, 2)
+		require.NoError(t, err)
+
+		_, err = client.UpdateStreamTags(ctx, []string{"english"})
+		require.NoError(t, err)
+
+		channels, err := client.GetChannels(ctx, gokick.NewChannelListFilter().SetSlug([]string{"scorfly"}))
+		require.NoError(t, err)
+		require.Len(t, channels.Result, 1)
+		assert.Equal(t, "new title", channels.Result[0].StreamTitle)
+		assert.Equal(t, "Fortnite", channels.Result[0].Category.Name)
+		assert.Equal(t, []string{"english"}, server.ChannelTags(721956))
+	})
+
+	t.Run("chat", func(t *testing.T) {
+		response, err := client.SendChatMessage(ctx, nil, "hello", nil, gokick.MessageTypeBot)
+		require.NoError(t, err)
+		assert.True(t, response.Result.IsSent)
+
+		messages := server.ChatMessages()
+		require.NotEmpty(t, messages)
+		assert.Equal(t, kicktest.ChatMessage{
+			MessageID:         response.Result.MessageID,
+			BroadcasterUserID: 721956,
+			SenderUserID:      721956,
+			Content:           "hello",
+			Type:              "bot",
+		}, messages[len(messages)-1])
+	})
+
+	t.Run("moderation", func(t *testing.T) {
+		reason := "spam"
+		_, err := client.BanUser(ctx, 721956, 117, nil, &reason)
+		require.NoError(t, err)
+		assert.Equal(t, []kicktest.Ban{{BroadcasterUserID: 721956, UserID: 117, ModeratorUserID: 721956, Reason: "spam"}}, server.Bans())
+
+		_, err = client.UnbanUser(ctx, 721956, 117)
+		require.NoError(t, err)
+		assert.Empty(t, server.Bans())
+	})
+
+	t.Run("livestreams", func(t *testing.T) {
+		server.AddLivestream(gokick.LivestreamResponse{BroadcasterUserID: 1, ViewerCount: 10, Language: "en"})
+		server.AddLivestream(gokick.LivestreamResponse{BroadcasterUserID: 2, ViewerCount: 30, Language: "en"})
+		server.AddLivestream(gokick.LivestreamResponse{BroadcasterUserID: 3, ViewerCount: 20, Language: "fr"})
+		server.SetLivestreamsStats(gokick.LivestreamStatsResponse{ViewerCount: 60})
+
+		livestreams, err := client.GetLivestreams(ctx, gokick.NewLivestreamListFilter().
+			SetLanguage("en").
+			SetSort(gokick.LivestreamSortViewerCount).
+			SetLimit(1))
+		require.NoError(t, err)
+		require.Len(t, livestreams.Result, 1)
+		assert.Equal(t, 2, livestreams.Result[0].BroadcasterUserID)
+
+		stats, err := client.GetLivestreamsStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 60, stats.Result.ViewerCount)
+	})
+
+	t.Run("event subscriptions", func(t *testing.T) {
+		created, err := client.CreateSubscriptions(ctx, gokick.SubscriptionMethodWebhook, []gokick.SubscriptionRequest{
+			{Name: gokick.SubscriptionNameChatMessage, Version: 1},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, created.Result, 1)
+
+		subscriptions, err := client.GetSubscriptions(ctx)
+		require.NoError(t, err)
+		require.Len(t, subscriptions.Result, 1)
+		assert.Equal(t, "chat.message.sent", subscriptions.Result[0].Event)
+		assert.Equal(t, 721956, subscriptions.Result[0].BroadcasterUserID)
+
+		_, err = client.DeleteSubscriptions(ctx, gokick.NewSubscriptionToDeleteFilter().SetIDs([]string{created.Result[0].SubscriptionID}))
+		require.NoError(t, err)
+		assert.Empty(t, server.Subscriptions())
+	})
+
+	t.Run("kicks leaderboard", func(t *testing.T) {
+		server.SetKicksLeaderboard(721956, gokick.KicksLeaderboardResponse{
+			Week: []gokick.KicksLeaderboardEntry{{Rank: 1, UserID: 117}, {Rank: 2, UserID: 118}},
+		})
+
+		leaderboard, err := client.GetKicksLeaderboard(ctx, gokick.NewKicksLeaderboardFilter().SetTop(1))
+		require.NoError(t, err)
+		assert.Equal(t, []gokick.KicksLeaderboardEntry{{Rank: 1, UserID: 117}}, leaderboard.Result.Week)
+		assert.Empty(t, leaderboard.Result.Lifetime)
+	})
+
+	t.Run("public key", func(t *testing.T) {
+		expected, err := server.PublicKeyPEM()
+		require.NoError(t, err)
+
+		publicKey, err := client.GetPublicKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expected, publicKey.Result.PublicKey)
+	})
+}
+
+func TestServerWebhooks(t *testing.T) {
+	server := setupServer(t)
+
+	publicKey, err := server.PublicKeyPEM()
+	require.NoError(t, err)
+
+	provider, err := gokick.NewStaticPublicKeyProvider(publicKey)
+	require.NoError(t, err)
+
+	received := make(chan *gokick.ChatMessageEvent, 2)
+
+	handler := gokick.NewWebhookHandler()
+	handler.SetVerifier(&gokick.EventVerifier{PublicKey: provider})
+	handler.OnChatMessage(func(_ context.Context, event *gokick.ChatMessageEvent) { received <- event })
+
+	target := httptest.NewServer(handler)
+	t.Cleanup(target.Close)
+
+	t.Run("send webhook", func(t *testing.T) {
+		response, err := server.SendWebhook(context.Background(), target.URL, kicktest.Webhook{
+			Subscription: gokick.SubscriptionNameChatMessage,
+			Payload:      gokick.ChatMessageEvent{MessageID: "message", Content: "hello"},
+		})
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "hello", (<-received).Content)
+	})
+
+	t.Run("publish to subscriptions", func(t *testing.T) {
+		server.SetWebhookURL(target.URL)
+		server.AddSubscription(gokick.EventResponse{ID: "sub", BroadcasterUserID: 721956, Event: "chat.message.sent", Version: 1})
+
+		delivered, err := server.PublishEvent(
+			context.Background(),
+			721956,
+			gokick.SubscriptionNameChatMessage,
+			gokick.ChatMessageEvent{Content: "published"},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, "published", (<-received).Content)
+
+		delivered, err = server.PublishEvent(context.Background(), 1, gokick.SubscriptionNameChatMessage, gokick.ChatMessageEvent{})
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		request, err := server.NewWebhookRequest(context.Background(), target.URL, kicktest.Webhook{
+			Subscription: gokick.SubscriptionNameChatMessage,
+			Payload:      []byte(`{"content":"hello"}`),
+		})
+		require.NoError(t, err)
+
+		request.Header.Set("Kick-Event-Message-Id", "another message")
+
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	})
+}
+
+func TestSamplePayload(t *testing.T) {
+	server := setupServer(t)
+
+	publicKey, err := server.PublicKeyPEM()
+	require.NoError(t, err)
+
+	provider, err := gokick.NewStaticPublicKeyProvider(publicKey)
+	require.NoError(t, err)
+
+	verifier := &gokick.EventVerifier{PublicKey: provider}
+
+	for subscription := gokick.SubscriptionNameChatMessage; subscription <= gokick.SubscriptionNameKicksGifted; subscription++ {
+		t.Run(subscription.String(), func(t *testing.T) {
+			payload := kicktest.SamplePayload(subscription)
+			require.NotNil(t, payload)
+
+			request, err := server.NewWebhookRequest(context.Background(), "/webhook", kicktest.Webhook{
+				Subscription: subscription,
+				Payload:      payload,
+			})
+			require.NoError(t, err)
+
+			event, err := verifier.ParseRequestEvent(request)
+			require.NoError(t, err)
+			assert.IsType(t, payload, event.Payload)
+			assert.Equal(t, payload, event.Payload)
+		})
+	}
+}
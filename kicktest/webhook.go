@@ -0,0 +1,178 @@
+package kicktest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/scorfly/gokick"
+)
+
+const testKeyBits = 2048
+
+// Webhook describes a webhook delivery.
+type Webhook struct {
+	Subscription   gokick.SubscriptionName
+	Version        int
+	SubscriptionID string
+	// MessageID and Timestamp are generated when empty.
+	MessageID string
+	Timestamp time.Time
+	// Payload is encoded as JSON, unless it is already a []byte or a json.RawMessage.
+	Payload any
+}
+
+// PrivateKey returns the key the Server signs webhooks with.
+func (s *Server) PrivateKey() (*rsa.PrivateKey, error) {
+	s.keyOnce.Do(func() {
+		s.privateKey, s.keyErr = rsa.GenerateKey(rand.Reader, testKeyBits)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.privateKey, s.keyErr
+}
+
+// RotateKey replaces the key the Server signs webhooks with, as KICK would when rotating its key.
+func (s *Server) RotateKey() error {
+	_, err := s.PrivateKey()
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, testKeyBits)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.privateKey = privateKey
+	s.mu.Unlock()
+
+	return nil
+}
+
+// PublicKeyPEM returns the PEM encoded public key matching PrivateKey, as served by the public key endpoint.
+func (s *Server) PublicKeyPEM() (string, error) {
+	privateKey, err := s.PrivateKey()
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// SetWebhookURL sets where PublishEvent delivers webhooks.
+func (s *Server) SetWebhookURL(url string) {
+	s.mu.Lock()
+	s.webhookURL = url
+	s.mu.Unlock()
+}
+
+// PublishEvent delivers payload to the webhook URL once per subscription matching the event and the broadcaster.
+// It returns the number of webhooks delivered.
+func (s *Server) PublishEvent(
+	ctx context.Context,
+	broadcasterUserID int,
+	subscription gokick.SubscriptionName,
+	payload any,
+) (int, error) {
+	s.mu.Lock()
+	url := s.webhookURL
+	var matching []gokick.EventResponse
+	for _, e := range s.subscriptions {
+		if e.BroadcasterUserID == broadcasterUserID && e.Event == subscription.String() {
+			matching = append(matching, e)
+		}
+	}
+	s.mu.Unlock()
+
+	if url == "" {
+		return 0, fmt.Errorf("webhook URL is not set")
+	}
+
+	for i, e := range matching {
+		response, err := s.SendWebhook(ctx, url, Webhook{
+			Subscription:   subscription,
+			Version:        e.Version,
+			SubscriptionID: e.ID,
+			Payload:        payload,
+		})
+		if err != nil {
+			return i, err
+		}
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return i, fmt.Errorf("webhook answered with status code %d", response.StatusCode)
+		}
+	}
+
+	return len(matching), nil
+}
+
+// SendWebhook signs webhook and POSTs it to url with the Kick-Event-* headers.
+func (s *Server) SendWebhook(ctx context.Context, url string, webhook Webhook) (*http.Response, error) {
+	request, err := s.NewWebhookRequest(ctx, url, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.DefaultClient.Do(request)
+}
+
+// NewWebhookRequest builds the signed request SendWebhook sends.
+func (s *Server) NewWebhookRequest(ctx context.Context, url string, webhook Webhook) (*http.Request, error) {
+	privateKey, err := s.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebhookRequest(ctx, privateKey, url, webhook)
+}
+
+// NewWebhookRequest builds a webhook request to url, with the Kick-Event-* headers, signed with privateKey.
+func NewWebhookRequest(ctx context.Context, privateKey *rsa.PrivateKey, url string, webhook Webhook) (*http.Request, error) {
+	var body []byte
+	switch payload := webhook.Payload.(type) {
+	case []byte:
+		body = payload
+	case json.RawMessage:
+		body = payload
+	default:
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+	}
+
+	if webhook.MessageID == "" {
+		webhook.MessageID = randomID()
+	}
+
+	if webhook.Version == 0 {
+		webhook.Version = 1
+	}
+
+	return gokick.NewSignedWebhookRequest(ctx, privateKey, url, gokick.WebhookHeaders{
+		Subscription:   webhook.Subscription,
+		Version:        strconv.Itoa(webhook.Version),
+		MessageID:      webhook.MessageID,
+		SubscriptionID: webhook.SubscriptionID,
+		Timestamp:      webhook.Timestamp,
+	}, body)
+}
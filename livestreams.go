@@ -3,6 +3,7 @@ package gokick
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -89,6 +90,7 @@ func (c *Client) GetLivestreams(ctx context.Context, filter LivestreamListFilter
 	response, err := makeRequest[[]LivestreamResponse](
 		ctx,
 		c,
+		Operation{Name: "GetLivestreams"},
 		http.MethodGet,
 		fmt.Sprintf("/public/v1/livestreams%s", filter.ToQueryString()),
 		http.StatusOK,
@@ -101,10 +103,36 @@ func (c *Client) GetLivestreams(ctx context.Context, filter LivestreamListFilter
 	return LivestreamsResponseWrapper(response), nil
 }
 
+// Livestreams iterates over the livestreams matching filter. KICK does not paginate livestreams, so a single
+// request is made, when the iteration starts, and at most the limit of filter livestreams are yielded. The iteration
+// stops after yielding an error, e.g. when ctx is canceled.
+func (c *Client) Livestreams(ctx context.Context, filter LivestreamListFilter) iter.Seq2[LivestreamResponse, error] {
+	return func(yield func(LivestreamResponse, error) bool) {
+		err := ctx.Err()
+		if err != nil {
+			yield(LivestreamResponse{}, err)
+			return
+		}
+
+		response, err := c.GetLivestreams(ctx, filter)
+		if err != nil {
+			yield(LivestreamResponse{}, err)
+			return
+		}
+
+		for _, livestream := range response.Result {
+			if !yield(livestream, nil) {
+				return
+			}
+		}
+	}
+}
+
 func (c *Client) GetLivestreamsStats(ctx context.Context) (LivestreamStatsResponseWrapper, error) {
 	response, err := makeRequest[LivestreamStatsResponse](
 		ctx,
 		c,
+		Operation{Name: "GetLivestreamsStats"},
 		http.MethodGet,
 		"/public/v1/livestreams/stats",
 		http.StatusOK,
@@ -289,3 +289,51 @@ func TestGetLivestreamsStatsSuccess(t *testing.T) {
 	assert.Equal(t, "thumbnail_url", LivestreamsResponse.Result.Thumbnail)
 	assert.Equal(t, 167, LivestreamsResponse.Result.ViewerCount)
 }
+
+func TestLivestreamsError(t *testing.T) {
+	t.Run("request error", func(t *testing.T) {
+		kickClient := setupMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"internal server error", "data":null}`)
+		})
+
+		var errs []error
+		for _, err := range kickClient.Livestreams(context.Background(), gokick.NewLivestreamListFilter()) {
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 1)
+		require.EqualError(t, errs[0], "Error 500: internal server error")
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		requested := false
+		kickClient := setupMockClient(t, func(_ http.ResponseWriter, _ *http.Request) { requested = true })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		for _, err := range kickClient.Livestreams(ctx, gokick.NewLivestreamListFilter()) {
+			require.ErrorIs(t, err, context.Canceled)
+		}
+
+		assert.False(t, requested)
+	})
+}
+
+func TestLivestreamsSuccess(t *testing.T) {
+	var query string
+	kickClient := setupMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `{"message":"success", "data":[{"broadcaster_user_id":117}, {"broadcaster_user_id":118}]}`)
+	})
+
+	var ids []int
+	for livestream, err := range kickClient.Livestreams(context.Background(), gokick.NewLivestreamListFilter().SetLimit(2)) {
+		require.NoError(t, err)
+		ids = append(ids, livestream.BroadcasterUserID)
+	}
+
+	assert.Equal(t, []int{117, 118}, ids)
+	assert.Equal(t, "limit=2", query)
+}
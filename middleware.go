@@ -0,0 +1,50 @@
+package gokick
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends HTTP requests. *http.Client is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to a Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer sending the requests of a Client, e.g. to log, measure or trace them, add headers, or
+// answer them without calling next in tests. The Operation of a request is read with OperationFromContext.
+//
+// Middlewares see every attempt of a request: the retries and the attempt following a token refresh, whose
+// Authorization header is already set, as well as the authentication requests.
+type Middleware func(next Doer) Doer
+
+// Operation describes the Client method a request is made by.
+type Operation struct {
+	// Name is the name of the method, e.g. "SendChatMessage".
+	Name string
+	// Scopes are the scopes the user access token needs, empty when any token is accepted.
+	Scopes []Scope
+}
+
+const operationKey contextKey = "operation"
+
+// OperationFromContext returns the operation of a request, from its context.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	operation, ok := ctx.Value(operationKey).(Operation)
+	return operation, ok
+}
+
+// chainMiddlewares wraps doer with middlewares, the first one being the outermost.
+func chainMiddlewares(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+
+	return doer
+}
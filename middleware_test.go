@@ -0,0 +1,160 @@
+package gokick_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordOperations(operations *[]gokick.Operation) gokick.Middleware {
+	return func(next gokick.Doer) gokick.Doer {
+		return gokick.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			operation, ok := gokick.OperationFromContext(req.Context())
+			if ok {
+				*operations = append(*operations, operation)
+			}
+
+			return next.Do(req)
+		})
+	}
+}
+
+func TestMiddlewareError(t *testing.T) {
+	kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+		UserAccessToken: "access-token",
+		Middlewares: []gokick.Middleware{
+			func(_ gokick.Doer) gokick.Doer {
+				return gokick.DoerFunc(func(_ *http.Request) (*http.Response, error) {
+					return nil, fmt.Errorf("circuit open")
+				})
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = kickClient.GetChannels(context.Background(), gokick.NewChannelListFilter())
+	require.EqualError(t, err, "failed to make request: circuit open")
+}
+
+func TestMiddlewareSuccess(t *testing.T) {
+	t.Run("operation and order", func(t *testing.T) {
+		var calls []string
+		trace := func(name string) gokick.Middleware {
+			return func(next gokick.Doer) gokick.Doer {
+				return gokick.DoerFunc(func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name)
+					return next.Do(req)
+				})
+			}
+		}
+
+		var operations []gokick.Operation
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "middleware", r.Header.Get("X-Trace"))
+			fmt.Fprint(w, `{"message":"success", "data":{"is_sent":true, "message_id":"117"}}`)
+		}))
+		t.Cleanup(server.Close)
+
+		kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+			UserAccessToken: "access-token",
+			APIBaseURL:      server.URL,
+			Middlewares: []gokick.Middleware{
+				trace("first"),
+				trace("second"),
+				recordOperations(&operations),
+				func(next gokick.Doer) gokick.Doer {
+					return gokick.DoerFunc(func(req *http.Request) (*http.Response, error) {
+						req.Header.Set("X-Trace", "middleware")
+						return next.Do(req)
+					})
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = kickClient.SendChatMessage(context.Background(), nil, "hello", nil, gokick.MessageTypeUser)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"first", "second"}, calls)
+		assert.Equal(t, []gokick.Operation{{Name: "SendChatMessage", Scopes: []gokick.Scope{gokick.ScopeChatWrite}}}, operations)
+	})
+
+	t.Run("mock operation", func(t *testing.T) {
+		kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+			UserAccessToken: "access-token",
+			APIBaseURL:      "http://localhost:0",
+			Middlewares: []gokick.Middleware{
+				func(next gokick.Doer) gokick.Doer {
+					return gokick.DoerFunc(func(req *http.Request) (*http.Response, error) {
+						if operation, _ := gokick.OperationFromContext(req.Context()); operation.Name != "GetCategory" {
+							return next.Do(req)
+						}
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{},
+							Body:       io.NopCloser(strings.NewReader(`{"message":"success", "data":{"id":117, "name":"Hubert"}}`)),
+							Request:    req,
+						}, nil
+					})
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		response, err := kickClient.GetCategory(context.Background(), 117)
+		require.NoError(t, err)
+		assert.Equal(t, "Hubert", response.Result.Name)
+	})
+
+	t.Run("retries and token refresh", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/oauth/token" {
+				fmt.Fprint(w, `{"access_token":"new-access-token", "refresh_token":"new-refresh-token", "expires_in":3600}`)
+				return
+			}
+
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"message":"Unauthorized", "data":null}`)
+				return
+			}
+
+			fmt.Fprint(w, `{"message":"success", "data":[]}`)
+		}))
+		t.Cleanup(server.Close)
+
+		var operations []gokick.Operation
+		kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+			UserAccessToken:  "access-token",
+			UserRefreshToken: "refresh-token",
+			ClientID:         "client-id",
+			ClientSecret:     "client-secret",
+			APIBaseURL:       server.URL,
+			AuthBaseURL:      server.URL,
+			Middlewares:      []gokick.Middleware{recordOperations(&operations)},
+		})
+		require.NoError(t, err)
+
+		_, err = kickClient.GetUsers(context.Background(), gokick.NewUserListFilter())
+		require.NoError(t, err)
+
+		names := make([]string, len(operations))
+		for i, operation := range operations {
+			names[i] = operation.Name
+		}
+		assert.Equal(t, []string{"GetUsers", "RefreshToken", "GetUsers"}, names)
+		assert.Equal(t, []gokick.Scope{gokick.ScopeUserRead}, operations[0].Scopes)
+		assert.Empty(t, operations[1].Scopes)
+	})
+}
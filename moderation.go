@@ -47,6 +47,7 @@ func (c *Client) BanUser(
 	response, err := makeRequest[BanUserResponse](
 		ctx,
 		c,
+		Operation{Name: "BanUser", Scopes: []Scope{ScopeModerationBan}},
 		http.MethodPost,
 		"/public/v1/moderation/bans",
 		http.StatusOK,
@@ -82,6 +83,7 @@ func (c *Client) UnbanUser(
 	response, err := makeRequest[BanUserResponse](
 		ctx,
 		c,
+		Operation{Name: "UnbanUser", Scopes: []Scope{ScopeModerationBan}},
 		http.MethodDelete,
 		"/public/v1/moderation/bans",
 		http.StatusOK,
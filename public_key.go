@@ -15,6 +15,7 @@ func (c *Client) GetPublicKey(ctx context.Context) (PublicKeyResponseWrapper, er
 	response, err := makeRequest[PublicKeyResponse](
 		ctx,
 		c,
+		Operation{Name: "GetPublicKey"},
 		http.MethodGet,
 		"/public/v1/public-key",
 		http.StatusOK,
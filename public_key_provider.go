@@ -0,0 +1,238 @@
+package gokick
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPublicKeyRefreshInterval    = 24 * time.Hour
+	defaultPublicKeyMinRefreshInterval = time.Minute
+)
+
+// PublicKeyProvider provides the public key verifying the signature of webhooks.
+type PublicKeyProvider interface {
+	// PublicKey returns the current key.
+	PublicKey(ctx context.Context) (*rsa.PublicKey, error)
+	// RefreshPublicKey is called when a signature does not verify, in case the key has been rotated.
+	// It returns the key to verify the signature again with.
+	RefreshPublicKey(ctx context.Context) (*rsa.PublicKey, error)
+}
+
+// StaticPublicKeyProvider always provides the same key.
+type StaticPublicKeyProvider struct {
+	key *rsa.PublicKey
+}
+
+// NewStaticPublicKeyProvider parses a PEM encoded public key.
+func NewStaticPublicKeyProvider(pemKey string) (*StaticPublicKeyProvider, error) {
+	key, err := parsePublicKey([]byte(pemKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %v", err)
+	}
+
+	return &StaticPublicKeyProvider{key: &key}, nil
+}
+
+// NewStaticPublicKeyProviderFromKey provides key, e.g. the public key of the private key given to SignEvent.
+func NewStaticPublicKeyProviderFromKey(key *rsa.PublicKey) *StaticPublicKeyProvider {
+	return &StaticPublicKeyProvider{key: key}
+}
+
+func (p *StaticPublicKeyProvider) PublicKey(_ context.Context) (*rsa.PublicKey, error) {
+	return p.key, nil
+}
+
+func (p *StaticPublicKeyProvider) RefreshPublicKey(_ context.Context) (*rsa.PublicKey, error) {
+	return p.key, nil
+}
+
+type CachedPublicKeyProviderOptions struct {
+	// RefreshInterval is the duration after which the key is fetched again (24 hours by default).
+	RefreshInterval time.Duration
+	// MinRefreshInterval is the minimum duration between two fetch attempts, successful or not (1 minute by
+	// default). Neither the signatures that do not verify nor an outage of the API trigger more fetches.
+	MinRefreshInterval time.Duration
+}
+
+// CachedPublicKeyProvider fetches the key with Client.GetPublicKey and caches it. The key is fetched again
+// periodically, and when a signature does not verify, at most once per MinRefreshInterval. Concurrent calls share a
+// single fetch, and the other calls never wait for the API. While the API is unreachable, the last key fetched keeps
+// being used. It is safe for concurrent use.
+type CachedPublicKeyProvider struct {
+	client      *Client
+	options     CachedPublicKeyProviderOptions
+	mu          sync.Mutex
+	key         *rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	lastErr     error
+	fetch       *publicKeyFetch
+}
+
+// publicKeyFetch is a fetch in flight, shared by every call waiting for the key.
+type publicKeyFetch struct {
+	done chan struct{}
+	key  *rsa.PublicKey
+	err  error
+}
+
+func NewCachedPublicKeyProvider(client *Client, options CachedPublicKeyProviderOptions) *CachedPublicKeyProvider {
+	if options.RefreshInterval == 0 {
+		options.RefreshInterval = defaultPublicKeyRefreshInterval
+	}
+
+	if options.MinRefreshInterval == 0 {
+		options.MinRefreshInterval = defaultPublicKeyMinRefreshInterval
+	}
+
+	return &CachedPublicKeyProvider{client: client, options: options}
+}
+
+func (p *CachedPublicKeyProvider) PublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	return p.get(ctx, p.options.RefreshInterval)
+}
+
+func (p *CachedPublicKeyProvider) RefreshPublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	return p.get(ctx, p.options.MinRefreshInterval)
+}
+
+// get returns the key when it was fetched less than maxAge ago, or when the last fetch attempt is more recent than
+// MinRefreshInterval. Otherwise it fetches the key, or waits for the fetch in flight.
+func (p *CachedPublicKeyProvider) get(ctx context.Context, maxAge time.Duration) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+
+	fresh := p.key != nil && time.Since(p.fetchedAt) < maxAge
+	throttled := !p.attemptedAt.IsZero() && time.Since(p.attemptedAt) < p.options.MinRefreshInterval
+	if fresh || (throttled && p.fetch == nil) {
+		key, err := p.cachedLocked()
+		p.mu.Unlock()
+
+		return key, err
+	}
+
+	fetch := p.fetch
+	if fetch == nil {
+		fetch = &publicKeyFetch{done: make(chan struct{})}
+		p.fetch, p.attemptedAt = fetch, time.Now()
+		p.mu.Unlock()
+
+		p.run(ctx, fetch)
+	} else {
+		p.mu.Unlock()
+	}
+
+	select {
+	case <-fetch.done:
+		return fetch.key, fetch.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run fetches the key without holding p.mu, records the result and wakes up the calls waiting for it.
+func (p *CachedPublicKeyProvider) run(ctx context.Context, fetch *publicKeyFetch) {
+	key, err := p.fetchKey(ctx)
+
+	p.mu.Lock()
+	if err == nil {
+		p.key, p.fetchedAt, p.lastErr = key, time.Now(), nil
+	} else {
+		p.lastErr = err
+	}
+	fetch.key, fetch.err = p.cachedLocked()
+	p.fetch = nil
+	p.mu.Unlock()
+
+	close(fetch.done)
+}
+
+func (p *CachedPublicKeyProvider) fetchKey(ctx context.Context) (*rsa.PublicKey, error) {
+	response, err := p.client.GetPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parsePublicKey([]byte(response.Result.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// cachedLocked returns the last key fetched or, when none was, the error of the last attempt.
+func (p *CachedPublicKeyProvider) cachedLocked() (*rsa.PublicKey, error) {
+	if p.key != nil {
+		return p.key, nil
+	}
+
+	return nil, fmt.Errorf("failed to fetch public key: %w", p.lastErr)
+}
+
+// defaultPublicKeyProvider provides DefaultEventPublicKey, parsed once per value of the variable.
+type defaultPublicKeyProvider struct {
+	mu  sync.Mutex
+	pem string
+	key *rsa.PublicKey
+}
+
+var defaultEventPublicKeyProvider = &defaultPublicKeyProvider{}
+
+// kickEventPublicKeyProvider provides KickEventPublicKey, parsed on first use.
+var kickEventPublicKeyProvider = sync.OnceValues(func() (*StaticPublicKeyProvider, error) {
+	return NewStaticPublicKeyProvider(KickEventPublicKey)
+})
+
+func (p *defaultPublicKeyProvider) PublicKey(_ context.Context) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.key == nil || p.pem != DefaultEventPublicKey {
+		key, err := parsePublicKey([]byte(DefaultEventPublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %v", err)
+		}
+
+		p.pem, p.key = DefaultEventPublicKey, &key
+	}
+
+	return p.key, nil
+}
+
+func (p *defaultPublicKeyProvider) RefreshPublicKey(ctx context.Context) (*rsa.PublicKey, error) {
+	return p.PublicKey(ctx)
+}
+
+// verifyEventSignatureWith verifies the signature of an event with the key of provider. When it does not verify,
+// the key is refreshed and, if it changed, the signature is verified again.
+func verifyEventSignatureWith(
+	ctx context.Context,
+	provider PublicKeyProvider,
+	eventSignature, messageID, timestamp string,
+	body []byte,
+) error {
+	signature := eventSignaturePayload(messageID, timestamp, body)
+
+	publicKey, err := provider.PublicKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = verifyEventValidity(publicKey, signature, []byte(eventSignature))
+	if err != nil {
+		refreshed, refreshErr := provider.RefreshPublicKey(ctx)
+		if refreshErr == nil && !refreshed.Equal(publicKey) {
+			err = verifyEventValidity(refreshed, signature, []byte(eventSignature))
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to verify event validity: %v", err)
+	}
+
+	return nil
+}
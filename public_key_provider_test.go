@@ -0,0 +1,179 @@
+package gokick_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/scorfly/gokick/kicktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingPublicKeyTransport struct {
+	fetches atomic.Int32
+}
+
+func (t *countingPublicKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/public/v1/public-key" {
+		t.fetches.Add(1)
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func setupPublicKeyProvider(
+	t *testing.T,
+	options gokick.CachedPublicKeyProviderOptions,
+) (*kicktest.Server, *gokick.CachedPublicKeyProvider, *countingPublicKeyTransport) {
+	t.Helper()
+
+	server := kicktest.NewServer()
+	t.Cleanup(server.Close)
+
+	transport := &countingPublicKeyTransport{}
+
+	client, err := server.NewClient(&gokick.ClientOptions{HTTPClient: &http.Client{Transport: transport}})
+	require.NoError(t, err)
+
+	return server, gokick.NewCachedPublicKeyProvider(client, options), transport
+}
+
+func TestStaticPublicKeyProviderError(t *testing.T) {
+	_, err := gokick.NewStaticPublicKeyProvider("invalid key")
+	require.EqualError(t, err, "failed to parse public key: failed to decode public key")
+}
+
+func TestStaticPublicKeyProviderSuccess(t *testing.T) {
+	provider, err := gokick.NewStaticPublicKeyProvider(gokick.KickEventPublicKey)
+	require.NoError(t, err)
+
+	key, err := provider.PublicKey(context.Background())
+	require.NoError(t, err)
+
+	refreshed, err := provider.RefreshPublicKey(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, key, refreshed)
+}
+
+func TestCachedPublicKeyProviderError(t *testing.T) {
+	t.Run("API down", func(t *testing.T) {
+		server, provider, _ := setupPublicKeyProvider(t, gokick.CachedPublicKeyProviderOptions{})
+		server.Close()
+
+		_, err := provider.PublicKey(context.Background())
+		require.ErrorContains(t, err, "failed to fetch public key: failed to make request: ")
+	})
+
+	t.Run("failed fetches limited by the minimum interval", func(t *testing.T) {
+		server, provider, transport := setupPublicKeyProvider(t, gokick.CachedPublicKeyProviderOptions{})
+		server.Close()
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				_, err := provider.PublicKey(context.Background())
+				assert.ErrorContains(t, err, "failed to fetch public key: failed to make request: ")
+
+				_, err = provider.RefreshPublicKey(context.Background())
+				assert.ErrorContains(t, err, "failed to fetch public key: failed to make request: ")
+			})
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), transport.fetches.Load())
+	})
+}
+
+func TestCachedPublicKeyProviderSuccess(t *testing.T) {
+	t.Run("cached", func(t *testing.T) {
+		server, provider, transport := setupPublicKeyProvider(t, gokick.CachedPublicKeyProviderOptions{})
+
+		privateKey, err := server.PrivateKey()
+		require.NoError(t, err)
+
+		for range 3 {
+			key, err := provider.PublicKey(context.Background())
+			require.NoError(t, err)
+			assert.True(t, privateKey.PublicKey.Equal(key))
+		}
+
+		assert.Equal(t, int32(1), transport.fetches.Load())
+	})
+
+	t.Run("refresh limited by the minimum interval", func(t *testing.T) {
+		_, provider, transport := setupPublicKeyProvider(t, gokick.CachedPublicKeyProviderOptions{})
+
+		_, err := provider.PublicKey(context.Background())
+		require.NoError(t, err)
+
+		_, err = provider.RefreshPublicKey(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), transport.fetches.Load())
+	})
+
+	t.Run("last key kept while the API is down", func(t *testing.T) {
+		server, provider, transport := setupPublicKeyProvider(t, gokick.CachedPublicKeyProviderOptions{
+			RefreshInterval:    -1,
+			MinRefreshInterval: -1,
+		})
+
+		key, err := provider.PublicKey(context.Background())
+		require.NoError(t, err)
+
+		server.Close()
+
+		stale, err := provider.PublicKey(context.Background())
+		require.NoError(t, err)
+		assert.Same(t, key, stale)
+		assert.Equal(t, int32(2), transport.fetches.Load())
+	})
+
+	t.Run("concurrent calls share a single fetch", func(t *testing.T) {
+		_, provider, transport := setupPublicKeyProvider(t, gokick.CachedPublicKeyProviderOptions{})
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				_, err := provider.PublicKey(context.Background())
+				assert.NoError(t, err)
+			})
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), transport.fetches.Load())
+	})
+}
+
+func TestWebhookHandlerPublicKeyRotation(t *testing.T) {
+	server, provider, transport := setupPublicKeyProvider(t, gokick.CachedPublicKeyProviderOptions{MinRefreshInterval: -1})
+
+	var received int
+	handler := gokick.NewWebhookHandler()
+	handler.SetPublicKeyProvider(provider)
+	handler.OnChannelFollow(func(context.Context, *gokick.ChannelFollowEvent) { received++ })
+
+	receiver := httptest.NewServer(handler)
+	t.Cleanup(receiver.Close)
+
+	webhook := kicktest.Webhook{Subscription: gokick.SubscriptionNameChannelFollow, Payload: gokick.ChannelFollowEvent{}}
+
+	response, err := server.SendWebhook(context.Background(), receiver.URL, webhook)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	require.NoError(t, server.RotateKey())
+
+	response, err = server.SendWebhook(context.Background(), receiver.URL, webhook)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	assert.Equal(t, 2, received)
+	assert.Equal(t, int32(2), transport.fetches.Load())
+}
@@ -0,0 +1,99 @@
+package gokick
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket: Rate tokens are added every second, up to Burst tokens.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	burst := float64(max(limit.Burst, 1))
+
+	return &rateLimiter{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	tokens := l.tokens
+	l.mu.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	err := sleepContext(ctx, time.Duration(-tokens/l.rate*float64(time.Second)))
+	if err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func newRateLimiters(limits map[EndpointGroup]RateLimit) map[EndpointGroup]*rateLimiter {
+	limiters := make(map[EndpointGroup]*rateLimiter, len(limits))
+	for group, limit := range limits {
+		limiters[group] = newRateLimiter(limit)
+	}
+
+	return limiters
+}
+
+func endpointGroupOf(req *http.Request) EndpointGroup {
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/public/v1/chat"):
+		return EndpointGroupChat
+	case strings.HasPrefix(req.URL.Path, "/public/v1/moderation"):
+		return EndpointGroupModeration
+	case req.Method == http.MethodGet:
+		return EndpointGroupRead
+	default:
+		return EndpointGroupWrite
+	}
+}
+
+func (c *Client) waitRateLimit(req *http.Request) error {
+	if len(c.limiters) == 0 || !strings.HasPrefix(req.URL.Path, "/public/") {
+		return nil
+	}
+
+	limiter, ok := c.limiters[endpointGroupOf(req)]
+	if !ok {
+		return nil
+	}
+
+	return limiter.wait(req.Context())
+}
@@ -0,0 +1,88 @@
+package gokick_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRateLimitedMockClient(
+	t *testing.T,
+	limits map[gokick.EndpointGroup]gokick.RateLimit,
+	mockHandler http.HandlerFunc,
+) *gokick.Client {
+	t.Helper()
+
+	server := httptest.NewServer(mockHandler)
+	kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+		UserAccessToken: "access-token",
+		APIBaseURL:      server.URL,
+		RateLimits:      limits,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { server.Close() })
+
+	return kickClient
+}
+
+func TestRateLimitError(t *testing.T) {
+	kickClient := setupRateLimitedMockClient(t, map[gokick.EndpointGroup]gokick.RateLimit{
+		gokick.EndpointGroupRead: {Rate: 0.1, Burst: 1},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"message":"success", "data":{"id":117, "name":"Fortnite"}}`)
+	})
+
+	_, err := kickClient.GetCategory(context.Background(), 117)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	t.Cleanup(cancel)
+
+	_, err = kickClient.GetCategory(ctx, 117)
+	require.EqualError(t, err, "failed to make request: context deadline exceeded")
+}
+
+func TestRateLimitSuccess(t *testing.T) {
+	t.Run("paces requests of the limited group", func(t *testing.T) {
+		kickClient := setupRateLimitedMockClient(t, map[gokick.EndpointGroup]gokick.RateLimit{
+			gokick.EndpointGroupRead: {Rate: 20, Burst: 1},
+		}, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"message":"success", "data":{"id":117, "name":"Fortnite"}}`)
+		})
+
+		start := time.Now()
+		for range 4 {
+			_, err := kickClient.GetCategory(context.Background(), 117)
+			require.NoError(t, err)
+		}
+
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+	})
+
+	t.Run("other groups are not limited", func(t *testing.T) {
+		kickClient := setupRateLimitedMockClient(t, map[gokick.EndpointGroup]gokick.RateLimit{
+			gokick.EndpointGroupChat: {Rate: 0.1, Burst: 1},
+		}, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"message":"success", "data":{"id":117, "name":"Fortnite"}}`)
+		})
+
+		start := time.Now()
+		for range 4 {
+			_, err := kickClient.GetCategory(context.Background(), 117)
+			require.NoError(t, err)
+		}
+
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -11,6 +11,7 @@ import (
 func makeRequest[T any](
 	ctx context.Context,
 	request *Client,
+	operation Operation,
 	method string,
 	path string,
 	statusCode int,
@@ -20,40 +21,43 @@ func makeRequest[T any](
 
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return Response[T]{}, fmt.Errorf("failed to create request: %v", err)
+		return Response[T]{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	req = req.WithContext(context.WithValue(ctx, operationKey, operation))
+
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := request.do(req)
 	if err != nil {
-		return Response[T]{}, fmt.Errorf("failed to make request: %v", err)
+		return Response[T]{}, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNoContent {
-		return Response[T]{}, nil
+		metadata := newResponseMetadata(req, resp, nil)
+		request.notifyResponse(req.Context(), metadata)
+
+		return Response[T]{Metadata: metadata}, nil
 	}
 
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return Response[T]{}, fmt.Errorf("failed to read response body (KICK status code %d): %v", resp.StatusCode, err)
+		return Response[T]{}, fmt.Errorf("failed to read response body (KICK status code %d): %w", resp.StatusCode, err)
 	}
 
+	metadata := newResponseMetadata(req, resp, responseBody)
+	request.notifyResponse(req.Context(), metadata)
+
 	if resp.StatusCode != statusCode {
 		var errorOutput errorResponse
 
 		err = json.Unmarshal(responseBody, &errorOutput)
 		if err != nil {
-			return Response[T]{}, fmt.Errorf(
-				"failed to unmarshal error response (KICK status code: %d and body %q): %v",
-				resp.StatusCode,
-				string(responseBody),
-				err,
-			)
+			return Response[T]{}, unmarshalErrorResponseError(req, resp, responseBody, err)
 		}
 
-		return Response[T]{}, NewError(resp.StatusCode, errorOutput.Message)
+		return Response[T]{}, NewError(resp.StatusCode, errorOutput.Message).withResponse(req, resp, responseBody)
 	}
 
 	type successResponse struct {
@@ -65,16 +69,17 @@ func makeRequest[T any](
 	err = json.Unmarshal(responseBody, &success)
 	if err != nil {
 		return Response[T]{}, fmt.Errorf(
-			"failed to unmarshal response body (KICK status code %d and body %q): %v", resp.StatusCode, string(responseBody), err,
+			"failed to unmarshal response body (KICK status code %d and body %q): %w", resp.StatusCode, string(responseBody), err,
 		)
 	}
 
-	return Response[T](success), nil
+	return Response[T]{Result: success.Result, Metadata: metadata}, nil
 }
 
 func makeAuthRequest[T any](
 	ctx context.Context,
 	request *Client,
+	operation Operation,
 	method string,
 	path string,
 	statusCode int,
@@ -85,52 +90,68 @@ func makeAuthRequest[T any](
 	var response T
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return response, fmt.Errorf("failed to create request: %v", err)
+		return response, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	req = req.WithContext(context.WithValue(context.WithValue(ctx, authRequestKey, true), operationKey, operation))
+
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := request.do(req)
 	if err != nil {
-		return response, fmt.Errorf("failed to make request: %v", err)
+		return response, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNoContent {
+		request.notifyResponse(req.Context(), newResponseMetadata(req, resp, nil))
 		return response, nil
 	}
 
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return response, fmt.Errorf("failed to read response body (KICK status code %d): %v", resp.StatusCode, err)
+		return response, fmt.Errorf("failed to read response body (KICK status code %d): %w", resp.StatusCode, err)
 	}
 
+	request.notifyResponse(req.Context(), newResponseMetadata(req, resp, responseBody))
+
 	if resp.StatusCode != statusCode {
 		var errorOutput authErrorResponse
 
 		err = json.Unmarshal(responseBody, &errorOutput)
 		if err != nil {
-			return response, fmt.Errorf(
-				"failed to unmarshal error response (KICK status code: %d and body %q): %v",
-				resp.StatusCode,
-				string(responseBody),
-				err,
-			)
+			return response, unmarshalErrorResponseError(req, resp, responseBody, err)
 		}
 
 		if errorOutput.Message != "" {
-			return response, NewError(resp.StatusCode, errorOutput.Message)
+			return response, NewError(resp.StatusCode, errorOutput.Message).withResponse(req, resp, responseBody)
 		} else {
-			return response, NewError(resp.StatusCode, errorOutput.Error).WithDescription(errorOutput.ErrorDescription)
+			return response, NewError(resp.StatusCode, errorOutput.Error).
+				WithDescription(errorOutput.ErrorDescription).
+				withResponse(req, resp, responseBody)
 		}
 	}
 
 	err = json.Unmarshal(responseBody, &response)
 	if err != nil {
 		return response, fmt.Errorf(
-			"failed to unmarshal response body (KICK status code %d and body %q): %v", resp.StatusCode, string(responseBody), err,
+			"failed to unmarshal response body (KICK status code %d and body %q): %w", resp.StatusCode, string(responseBody), err,
 		)
 	}
 
 	return response, nil
 }
+
+// unmarshalErrorResponseError reports an error response whose body is not JSON, e.g. the HTML page of a proxy. It is
+// also matched as the Error of the response, so its status code can still be checked with errors.Is and errors.As.
+func unmarshalErrorResponseError(req *http.Request, resp *http.Response, body []byte, err error) error {
+	return causedError{
+		err: fmt.Errorf(
+			"failed to unmarshal error response (KICK status code: %d and body %q): %w",
+			resp.StatusCode,
+			string(body),
+			err,
+		),
+		cause: NewError(resp.StatusCode, "").withResponse(req, resp, body),
+	}
+}
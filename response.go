@@ -1,7 +1,33 @@
 package gokick
 
+import "net/http"
+
 type Response[T any] struct {
 	Result T
+	// Metadata describes the HTTP response Result was read from.
+	Metadata ResponseMetadata
 }
 
 type EmptyResponse struct{}
+
+// ResponseMetadata describes the HTTP response of a request, e.g. to log the request ID KICK sends in its headers,
+// or to read its rate limit headers.
+type ResponseMetadata struct {
+	// Method and Path identify the request, e.g. "GET" and "/public/v1/channels". Path does not include the query.
+	Method string
+	Path   string
+	// StatusCode, Header and Body are the raw response. Body is empty for a 204 response.
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func newResponseMetadata(req *http.Request, resp *http.Response, body []byte) ResponseMetadata {
+	return ResponseMetadata{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+}
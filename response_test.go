@@ -0,0 +1,97 @@
+package gokick_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type requestIDKey struct{}
+
+func TestResponseMetadataSuccess(t *testing.T) {
+	kickClient := setupMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "request-id")
+
+		switch r.Method {
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"forbidden", "data":null}`)
+		default:
+			fmt.Fprint(w, `{"message":"success", "data":[{"slug":"scorfly"}]}`)
+		}
+	})
+
+	var responses []gokick.ResponseMetadata
+	kickClient.OnResponse(func(ctx context.Context, metadata gokick.ResponseMetadata) {
+		assert.Equal(t, "trace", ctx.Value(requestIDKey{}))
+
+		_, ok := gokick.OperationFromContext(ctx)
+		assert.True(t, ok)
+		responses = append(responses, metadata)
+	})
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "trace")
+
+	t.Run("response", func(t *testing.T) {
+		response, err := kickClient.GetChannels(ctx, gokick.NewChannelListFilter().SetSlug([]string{"scorfly"}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "scorfly", response.Result[0].Slug)
+		assert.Equal(t, http.MethodGet, response.Metadata.Method)
+		assert.Equal(t, "/public/v1/channels", response.Metadata.Path)
+		assert.Equal(t, http.StatusOK, response.Metadata.StatusCode)
+		assert.Equal(t, "request-id", response.Metadata.Header.Get("X-Request-Id"))
+		assert.JSONEq(t, `{"message":"success", "data":[{"slug":"scorfly"}]}`, string(response.Metadata.Body))
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		responses = nil
+
+		_, err := kickClient.UpdateStreamTitle(ctx, "title")
+		require.NoError(t, err)
+
+		require.Len(t, responses, 1)
+		assert.Equal(t, http.MethodPatch, responses[0].Method)
+		assert.Equal(t, http.StatusNoContent, responses[0].StatusCode)
+		assert.Equal(t, "request-id", responses[0].Header.Get("X-Request-Id"))
+		assert.Empty(t, responses[0].Body)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		responses = nil
+
+		_, err := kickClient.SendChatMessage(ctx, nil, "hello", nil, gokick.MessageTypeUser)
+		require.ErrorIs(t, err, gokick.ErrForbidden)
+
+		require.Len(t, responses, 1)
+		assert.Equal(t, http.StatusForbidden, responses[0].StatusCode)
+		assert.Equal(t, "/public/v1/chat", responses[0].Path)
+	})
+}
+
+func TestResponseMetadataAuthSuccess(t *testing.T) {
+	kickClient := setupMockAuthClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "request-id")
+		fmt.Fprint(w, `{"access_token":"access-token", "token_type":"Bearer", "expires_in":3600}`)
+	})
+
+	var responses []gokick.ResponseMetadata
+	kickClient.OnResponse(func(_ context.Context, metadata gokick.ResponseMetadata) {
+		responses = append(responses, metadata)
+	})
+
+	_, err := kickClient.GetAppAccessToken(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, http.MethodPost, responses[0].Method)
+	assert.Equal(t, "/oauth/token", responses[0].Path)
+	assert.Equal(t, "request-id", responses[0].Header.Get("X-Request-Id"))
+}
@@ -0,0 +1,115 @@
+package gokick
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how Client retries requests answered with a transient error.
+// A nil policy disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry. It doubles on every following retry.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the computed backoff. A Retry-After header asking for a longer delay is not retried.
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, of the backoff that is randomly removed.
+	Jitter float64
+	// RetryableStatusCodes lists the response status codes that trigger a retry.
+	RetryableStatusCodes []int
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// nextDelay returns how long to wait before the next attempt, and whether the request should be retried at all.
+// attempt is the number of retries already done.
+func (p *RetryPolicy) nextDelay(attempt int, response *http.Response) (time.Duration, bool) {
+	if p == nil || attempt+1 >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if !slices.Contains(p.RetryableStatusCodes, response.StatusCode) {
+		return 0, false
+	}
+
+	if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now()); ok {
+		if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+			return 0, false
+		}
+
+		return retryAfter, true
+	}
+
+	return p.backoff(attempt), true
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseBackoff
+	for i := 0; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * min(p.Jitter, 1) * float64(delay))
+	}
+
+	return delay
+}
+
+// parseRetryAfter reads a Retry-After header value, either expressed in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
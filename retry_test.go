@@ -0,0 +1,192 @@
+package gokick_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRetryMockClient(t *testing.T, policy *gokick.RetryPolicy, mockHandler http.HandlerFunc) *gokick.Client {
+	t.Helper()
+
+	server := httptest.NewServer(mockHandler)
+	kickClient, err := gokick.NewClient(&gokick.ClientOptions{
+		UserAccessToken: "access-token",
+		APIBaseURL:      server.URL,
+		RetryPolicy:     policy,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { server.Close() })
+
+	return kickClient
+}
+
+func fastRetryPolicy() *gokick.RetryPolicy {
+	policy := gokick.DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+	policy.MaxBackoff = 10 * time.Millisecond
+
+	return policy
+}
+
+func TestRetryPolicyError(t *testing.T) {
+	t.Run("without policy", func(t *testing.T) {
+		var attempts atomic.Int32
+		kickClient := setupRetryMockClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"message":"bad gateway", "data":null}`)
+		})
+
+		_, err := kickClient.GetCategory(context.Background(), 117)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusBadGateway, kickError.Code())
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("max attempts reached", func(t *testing.T) {
+		var attempts atomic.Int32
+		kickClient := setupRetryMockClient(t, fastRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"message":"unavailable", "data":null}`)
+		})
+
+		_, err := kickClient.GetCategory(context.Background(), 117)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusServiceUnavailable, kickError.Code())
+		assert.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("status code not retryable", func(t *testing.T) {
+		var attempts atomic.Int32
+		kickClient := setupRetryMockClient(t, fastRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"bad request", "data":null}`)
+		})
+
+		_, err := kickClient.GetCategory(context.Background(), 117)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("retry after longer than max backoff", func(t *testing.T) {
+		var attempts atomic.Int32
+		kickClient := setupRetryMockClient(t, fastRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"message":"too many requests", "data":null}`)
+		})
+
+		_, err := kickClient.GetCategory(context.Background(), 117)
+
+		var kickError gokick.Error
+		require.ErrorAs(t, err, &kickError)
+		assert.Equal(t, http.StatusTooManyRequests, kickError.Code())
+		assert.Equal(t, int32(1), attempts.Load())
+	})
+
+	t.Run("context done while waiting", func(t *testing.T) {
+		policy := fastRetryPolicy()
+		policy.BaseBackoff = time.Minute
+		policy.MaxBackoff = time.Minute
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		t.Cleanup(cancel)
+
+		kickClient := setupRetryMockClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, err := kickClient.GetCategory(ctx, 117)
+		require.EqualError(t, err, "failed to make request: context deadline exceeded")
+	})
+}
+
+func TestRetryPolicySuccess(t *testing.T) {
+	t.Run("after transient errors", func(t *testing.T) {
+		var attempts atomic.Int32
+		kickClient := setupRetryMockClient(t, fastRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"message":"success", "data":{"id":117, "name":"Fortnite"}}`)
+		})
+
+		response, err := kickClient.GetCategory(context.Background(), 117)
+		require.NoError(t, err)
+		assert.Equal(t, "Fortnite", response.Result.Name)
+		assert.Equal(t, int32(3), attempts.Load())
+	})
+
+	t.Run("honors retry after", func(t *testing.T) {
+		policy := fastRetryPolicy()
+		policy.MaxBackoff = 2 * time.Second
+
+		var attempts atomic.Int32
+		kickClient := setupRetryMockClient(t, policy, func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"message":"success", "data":{"id":117, "name":"Fortnite"}}`)
+		})
+
+		start := time.Now()
+		_, err := kickClient.GetCategory(context.Background(), 117)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(2), attempts.Load())
+	})
+
+	t.Run("replays request body", func(t *testing.T) {
+		var (
+			attempts atomic.Int32
+			bodies   []string
+		)
+		kickClient := setupRetryMockClient(t, fastRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			bodies = append(bodies, string(body))
+
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `{"message":"success", "data":{"is_sent":true, "message_id":"message id"}}`)
+		})
+
+		_, err := kickClient.SendChatMessage(context.Background(), nil, "message", nil, gokick.MessageTypeBot)
+		require.NoError(t, err)
+		require.Len(t, bodies, 2)
+		assert.Equal(t, bodies[0], bodies[1])
+		assert.JSONEq(t, `{"content":"message", "type":"bot"}`, bodies[1])
+	})
+}
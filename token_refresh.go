@@ -0,0 +1,237 @@
+package gokick
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultTokenRefreshSkew = time.Minute
+	// minTokenRefreshInterval is the minimum delay between two refreshes of RunTokenRefresher, whatever the lifetime
+	// of the tokens.
+	minTokenRefreshInterval = 30 * time.Second
+)
+
+var errUnknownTokenExpiry = errors.New("failed to find the user access token expiry: it is inactive, or has no expiry")
+
+// refreshCall is a refresh in flight, shared by every request waiting for a new user access token.
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+// refreshUserToken refreshes the user access token, unless staleAccessToken has already been replaced.
+// Concurrent calls share a single RefreshToken request.
+func (c *Client) refreshUserToken(ctx context.Context, staleAccessToken string) error {
+	var refreshToken string
+
+	return c.singleFlight(
+		ctx,
+		&c.refresh,
+		func() bool {
+			refreshToken = c.options.UserRefreshToken
+			return c.options.UserAccessToken == staleAccessToken
+		},
+		func(ctx context.Context) error { return c.refreshToken(ctx, refreshToken) },
+	)
+}
+
+// singleFlight runs renew, unless stale (called with c.mu held) reports that the token has already been
+// replaced. When a renewal is already in flight in *call, it waits for it instead.
+func (c *Client) singleFlight(
+	ctx context.Context,
+	call **refreshCall,
+	stale func() bool,
+	renew func(ctx context.Context) error,
+) error {
+	c.mu.Lock()
+	if !stale() {
+		c.mu.Unlock()
+		return nil
+	}
+
+	inFlight := *call
+	if inFlight != nil {
+		c.mu.Unlock()
+
+		select {
+		case <-inFlight.done:
+			return inFlight.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	inFlight = &refreshCall{done: make(chan struct{})}
+	*call = inFlight
+	c.mu.Unlock()
+
+	inFlight.err = renew(ctx)
+
+	c.mu.Lock()
+	*call = nil
+	c.mu.Unlock()
+	close(inFlight.done)
+
+	return inFlight.err
+}
+
+func (c *Client) refreshToken(ctx context.Context, refreshToken string) error {
+	token, err := c.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		return fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	var expiresAt time.Time
+	if token.ExpiresIn > 0 {
+		expiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	c.mu.Lock()
+	c.options.UserAccessToken = token.AccessToken
+	c.options.UserRefreshToken = token.RefreshToken
+	c.userAccessTokenExpiresAt = expiresAt
+	callback := c.callbacks.onUserAccessTokenRefreshed
+	c.mu.Unlock()
+
+	if store := c.options.TokenStore; store != nil {
+		err = store.Save(ctx, Token{AccessToken: token.AccessToken, RefreshToken: token.RefreshToken, ExpiresAt: expiresAt})
+		if err != nil {
+			return fmt.Errorf("failed to save token: %w", err)
+		}
+	}
+
+	if callback != nil {
+		go callback(token.AccessToken, token.RefreshToken)
+	}
+
+	return nil
+}
+
+// renewExpiringToken renews the token about to be sent when it expires within the refresh skew, and fetches
+// an app access token when there is none to send and AutoAppAccessToken is set.
+// Renewing an expiring token is best effort: when it fails the request is sent anyway, and a 401 triggers
+// another attempt.
+func (c *Client) renewExpiringToken(ctx context.Context) error {
+	token := c.selectToken(ctx)
+
+	c.mu.Lock()
+	userExpiresAt, appExpiresAt := c.userAccessTokenExpiresAt, c.appAccessTokenExpiresAt
+	c.mu.Unlock()
+
+	switch token.kind {
+	case tokenKindUser:
+		if c.canRefreshUserToken() && c.expiresSoon(userExpiresAt) {
+			_ = c.refreshUserToken(ctx, token.value)
+		}
+	case tokenKindApp, tokenKindNone:
+		if !c.canRenewAppToken() {
+			return nil
+		}
+
+		if token.value == "" {
+			return c.renewAppAccessToken(ctx, "")
+		}
+
+		if c.expiresSoon(appExpiresAt) {
+			_ = c.renewAppAccessToken(ctx, token.value)
+		}
+	case tokenKindBearer:
+	}
+
+	return nil
+}
+
+func (c *Client) canRenewToken(kind tokenKind) bool {
+	switch kind {
+	case tokenKindUser:
+		return c.canRefreshUserToken()
+	case tokenKindApp:
+		return c.canRenewAppToken()
+	case tokenKindNone, tokenKindBearer:
+	}
+
+	return false
+}
+
+// renewToken replaces a token rejected by the API.
+func (c *Client) renewToken(ctx context.Context, token requestToken) error {
+	if token.kind == tokenKindApp {
+		return c.renewAppAccessToken(ctx, token.value)
+	}
+
+	return c.refreshUserToken(ctx, token.value)
+}
+
+func (c *Client) expiresSoon(expiresAt time.Time) bool {
+	return !expiresAt.IsZero() && time.Until(expiresAt) <= c.options.TokenRefreshSkew
+}
+
+// setUserAccessTokenExpiry records the expiry of accessToken, if it is still the current user access token.
+func (c *Client) setUserAccessTokenExpiry(accessToken string, expiresAt time.Time) {
+	c.mu.Lock()
+	if accessToken != "" && c.options.UserAccessToken == accessToken {
+		c.userAccessTokenExpiresAt = expiresAt
+	}
+	c.mu.Unlock()
+}
+
+// UserAccessTokenExpiresAt returns the expiry of the user access token, zero when unknown.
+// It is known after a refresh, a TokenIntrospect call, or when set in ClientOptions or the TokenStore.
+func (c *Client) UserAccessTokenExpiresAt() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.userAccessTokenExpiresAt
+}
+
+// RunTokenRefresher refreshes the user access token TokenRefreshSkew before it expires, until ctx is done.
+// When the expiry is unknown it is looked up with TokenIntrospect. It is meant to be run in its own goroutine,
+// so that requests never wait for a refresh. Tokens living less than TokenRefreshSkew are refreshed at most every
+// 30 seconds.
+//
+// It returns ctx.Err() once ctx is done, or the error that stopped it (set a RetryPolicy to survive transient
+// errors). It stops when the expiry of the token cannot be found, rather than refreshing it in a loop.
+func (c *Client) RunTokenRefresher(ctx context.Context) error {
+	if !c.canRefreshUserToken() {
+		return errors.New("client ID, client secret and user refresh token must be set on Client to refresh token")
+	}
+
+	var lastRefresh time.Time
+	for {
+		c.mu.Lock()
+		accessToken, expiresAt := c.options.UserAccessToken, c.userAccessTokenExpiresAt
+		c.mu.Unlock()
+
+		if expiresAt.IsZero() && accessToken != "" {
+			_, err := c.TokenIntrospect(ctx)
+			if err != nil {
+				return err
+			}
+
+			expiresAt = c.UserAccessTokenExpiresAt()
+			if expiresAt.IsZero() {
+				return errUnknownTokenExpiry
+			}
+		}
+
+		// Each refresh rotates the refresh token: tokens living less than the skew are not refreshed back to back.
+		refreshAt := expiresAt.Add(-c.options.TokenRefreshSkew)
+		if earliest := lastRefresh.Add(minTokenRefreshInterval); !lastRefresh.IsZero() && refreshAt.Before(earliest) {
+			refreshAt = earliest
+		}
+
+		err := sleepContext(ctx, time.Until(refreshAt))
+		if err != nil {
+			return err
+		}
+
+		err = c.refreshUserToken(ctx, accessToken)
+		if err != nil {
+			return err
+		}
+		lastRefresh = time.Now()
+	}
+}
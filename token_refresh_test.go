@@ -0,0 +1,205 @@
+package gokick_test
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type refreshMock struct {
+	refreshes atomic.Int32
+	// validToken returns whether the API accepts the access token.
+	validToken func(token string) bool
+	exp        int
+	// expiresIn is the lifetime of the refreshed tokens in seconds, 7200 when zero, none when noExpiry is set.
+	expiresIn int
+	noExpiry  bool
+}
+
+func (m *refreshMock) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.URL.Path {
+	case "/oauth/token":
+		n := m.refreshes.Add(1)
+		time.Sleep(10 * time.Millisecond)
+
+		expiresIn := cmp.Or(m.expiresIn, 7200)
+		if m.noExpiry {
+			expiresIn = 0
+		}
+
+		return jsonResponse(http.StatusOK, fmt.Sprintf(
+			`{"access_token":"access-token-%d","refresh_token":"refresh-token-%d","expires_in":%d}`, n, n, expiresIn,
+		)), nil
+	case "/public/v1/token/introspect":
+		return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":{"active":true,"exp":%d}}`, m.exp)), nil
+	}
+
+	token := req.Header.Get("Authorization")[len("Bearer "):]
+	if m.validToken != nil && !m.validToken(token) {
+		return jsonResponse(http.StatusUnauthorized, `{"message":"Unauthorized"}`), nil
+	}
+
+	return jsonResponse(http.StatusOK, `{"data":{}}`), nil
+}
+
+func jsonResponse(code int, body string) *http.Response {
+	return &http.Response{StatusCode: code, Body: io.NopCloser(bytes.NewBufferString(body))}
+}
+
+func setupRefreshClient(t *testing.T, mock *refreshMock, expiresAt time.Time) *gokick.Client {
+	t.Helper()
+
+	client, err := gokick.NewClient(&gokick.ClientOptions{
+		UserAccessToken:          "access-token",
+		UserRefreshToken:         "refresh-token",
+		UserAccessTokenExpiresAt: expiresAt,
+		ClientID:                 "client-id",
+		ClientSecret:             "client-secret",
+		HTTPClient:               &http.Client{Transport: mock},
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestProactiveTokenRefreshSuccess(t *testing.T) {
+	t.Run("token far from expiry", func(t *testing.T) {
+		mock := &refreshMock{}
+		client := setupRefreshClient(t, mock, time.Now().Add(time.Hour))
+
+		_, err := client.GetCategory(context.Background(), 117)
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), mock.refreshes.Load())
+	})
+
+	t.Run("token expiring within the skew", func(t *testing.T) {
+		mock := &refreshMock{validToken: func(token string) bool { return token != "access-token" }}
+		client := setupRefreshClient(t, mock, time.Now().Add(10*time.Second))
+
+		_, err := client.GetCategory(context.Background(), 117)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), mock.refreshes.Load())
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), client.UserAccessTokenExpiresAt(), time.Minute)
+	})
+
+	t.Run("concurrent requests share a single refresh", func(t *testing.T) {
+		mock := &refreshMock{validToken: func(token string) bool { return token != "access-token" }}
+		client := setupRefreshClient(t, mock, time.Now().Add(-time.Second))
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := client.GetCategory(context.Background(), 117)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), mock.refreshes.Load())
+	})
+
+	t.Run("concurrent 401 share a single refresh", func(t *testing.T) {
+		mock := &refreshMock{validToken: func(token string) bool { return token != "access-token" }}
+		client := setupRefreshClient(t, mock, time.Time{})
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := client.GetCategory(context.Background(), 117)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), mock.refreshes.Load())
+	})
+}
+
+func TestReactiveTokenRefreshError(t *testing.T) {
+	mock := &refreshMock{validToken: func(string) bool { return false }}
+	client := setupRefreshClient(t, mock, time.Time{})
+
+	_, err := client.GetCategory(context.Background(), 117)
+	require.EqualError(t, err, `Error 401: Unauthorized`)
+	assert.Equal(t, int32(1), mock.refreshes.Load())
+}
+
+func TestTokenIntrospectExpirySuccess(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	client := setupRefreshClient(t, &refreshMock{exp: int(exp.Unix())}, time.Time{})
+
+	_, err := client.TokenIntrospect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, exp.Equal(client.UserAccessTokenExpiresAt()))
+}
+
+func TestRunTokenRefresherError(t *testing.T) {
+	t.Run("missing credentials", func(t *testing.T) {
+		client, err := gokick.NewClient(&gokick.ClientOptions{UserAccessToken: "access-token"})
+		require.NoError(t, err)
+
+		err = client.RunTokenRefresher(context.Background())
+		require.EqualError(t, err, "client ID, client secret and user refresh token must be set on Client to refresh token")
+	})
+
+	t.Run("unknown expiry", func(t *testing.T) {
+		mock := &refreshMock{noExpiry: true}
+		client := setupRefreshClient(t, mock, time.Now().Add(-time.Second))
+
+		err := client.RunTokenRefresher(context.Background())
+		require.EqualError(t, err, "failed to find the user access token expiry: it is inactive, or has no expiry")
+		assert.Equal(t, int32(1), mock.refreshes.Load())
+	})
+}
+
+func TestRunTokenRefresherSuccess(t *testing.T) {
+	mock := &refreshMock{exp: int(time.Now().Add(30 * time.Second).Unix())}
+	client := setupRefreshClient(t, mock, time.Time{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- client.RunTokenRefresher(ctx) }()
+
+	require.Eventually(t, func() bool { return mock.refreshes.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, int32(1), mock.refreshes.Load())
+}
+
+func TestRunTokenRefresherShortLivedTokens(t *testing.T) {
+	mock := &refreshMock{expiresIn: 30}
+	client := setupRefreshClient(t, mock, time.Now().Add(-time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- client.RunTokenRefresher(ctx) }()
+
+	require.Eventually(t, func() bool { return mock.refreshes.Load() == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, int32(1), mock.refreshes.Load(), "tokens living less than the skew are not refreshed back to back")
+}
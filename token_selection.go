@@ -0,0 +1,30 @@
+package gokick
+
+import "context"
+
+// WithAppAccessToken returns a context making the requests made with it use the app access token of the client,
+// even when a user access token is set.
+func WithAppAccessToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tokenSelectionKey, requestToken{kind: tokenKindApp})
+}
+
+// WithUserAccessToken returns a context making the requests made with it use the user access token of the client.
+func WithUserAccessToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tokenSelectionKey, requestToken{kind: tokenKindUser})
+}
+
+// WithBearerToken returns a context making the requests made with it use token, whatever the tokens of the
+// client. The client never refreshes this token.
+func WithBearerToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenSelectionKey, requestToken{kind: tokenKindBearer, value: token})
+}
+
+func tokenSelection(ctx context.Context) requestToken {
+	if ctx == nil {
+		return requestToken{}
+	}
+
+	selection, _ := ctx.Value(tokenSelectionKey).(requestToken)
+
+	return selection
+}
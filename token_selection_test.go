@@ -0,0 +1,92 @@
+package gokick_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenSelectionSuccess(t *testing.T) {
+	var authorization string
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/oauth/token" {
+			return jsonResponse(http.StatusOK, `{"access_token":"fetched-app-token","expires_in":3600}`), nil
+		}
+
+		authorization = req.Header.Get("Authorization")
+
+		return jsonResponse(http.StatusOK, `{"data":{}}`), nil
+	})
+
+	newClient := func(t *testing.T, options gokick.ClientOptions) *gokick.Client {
+		t.Helper()
+
+		options.HTTPClient = &http.Client{Transport: transport}
+
+		client, err := gokick.NewClient(&options)
+		require.NoError(t, err)
+
+		return client
+	}
+
+	testCases := map[string]struct {
+		options  gokick.ClientOptions
+		ctx      context.Context
+		expected string
+	}{
+		"user token by default": {
+			options:  gokick.ClientOptions{AppAccessToken: "app-token", UserAccessToken: "user-token"},
+			ctx:      context.Background(),
+			expected: "Bearer user-token",
+		},
+		"app token": {
+			options:  gokick.ClientOptions{AppAccessToken: "app-token", UserAccessToken: "user-token"},
+			ctx:      gokick.WithAppAccessToken(context.Background()),
+			expected: "Bearer app-token",
+		},
+		"user token": {
+			options:  gokick.ClientOptions{AppAccessToken: "app-token", UserAccessToken: "user-token"},
+			ctx:      gokick.WithUserAccessToken(context.Background()),
+			expected: "Bearer user-token",
+		},
+		"bearer token": {
+			options:  gokick.ClientOptions{AppAccessToken: "app-token", UserAccessToken: "user-token"},
+			ctx:      gokick.WithBearerToken(context.Background(), "other-token"),
+			expected: "Bearer other-token",
+		},
+		"app token fetched when missing": {
+			options: gokick.ClientOptions{
+				UserAccessToken:    "user-token",
+				ClientID:           "client-id",
+				ClientSecret:       "client-secret",
+				AutoAppAccessToken: true,
+			},
+			ctx:      gokick.WithAppAccessToken(context.Background()),
+			expected: "Bearer fetched-app-token",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t, tc.options)
+
+			_, err := client.GetCategory(tc.ctx, 117)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, authorization)
+		})
+	}
+}
+
+func TestTokenSelectionBearerNotRefreshed(t *testing.T) {
+	mock := &refreshMock{validToken: func(string) bool { return false }}
+	client := setupRefreshClient(t, mock, time.Time{})
+
+	_, err := client.GetCategory(gokick.WithBearerToken(context.Background(), "other-token"), 117)
+	require.EqualError(t, err, "Error 401: Unauthorized")
+	assert.Equal(t, int32(0), mock.refreshes.Load())
+}
@@ -0,0 +1,135 @@
+package gokick
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrTokenNotFound is returned by TokenStore.Load when no token has been saved yet.
+var ErrTokenNotFound = errors.New("token not found")
+
+// Token is the user token pair persisted by a TokenStore.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresAt is the expiry of AccessToken, zero when unknown.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// TokenStore persists the user tokens of a Client.
+//
+// The client loads the tokens when it is created, and saves them after every refresh, before the request that
+// triggered the refresh is retried. KICK rotates the refresh token on every refresh, so a token that is not saved
+// is lost for good.
+type TokenStore interface {
+	Load(ctx context.Context) (Token, error)
+	Save(ctx context.Context, token Token) error
+}
+
+// MemoryTokenStore keeps the tokens in memory. It is safe for concurrent use.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *Token
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (s *MemoryTokenStore) Load(_ context.Context) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return Token{}, ErrTokenNotFound
+	}
+
+	return *s.token, nil
+}
+
+func (s *MemoryTokenStore) Save(_ context.Context, token Token) error {
+	s.mu.Lock()
+	s.token = &token
+	s.mu.Unlock()
+
+	return nil
+}
+
+// FileTokenStore keeps the tokens in a JSON file. The file is replaced atomically on every save
+// and is only readable by the current user.
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (s *FileTokenStore) Load(_ context.Context) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Token{}, ErrTokenNotFound
+	}
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var token Token
+	err = json.Unmarshal(data, &token)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to unmarshal token file: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *FileTokenStore) Save(_ context.Context, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	err = tmp.Chmod(0o600)
+	if err == nil {
+		_, err = tmp.Write(data)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), s.path)
+	if err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+
+	return nil
+}
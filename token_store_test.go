@@ -0,0 +1,66 @@
+package gokick_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTokenStore(t *testing.T) {
+	store := gokick.NewMemoryTokenStore()
+
+	_, err := store.Load(context.Background())
+	require.ErrorIs(t, err, gokick.ErrTokenNotFound)
+
+	token := gokick.Token{AccessToken: "access-token", RefreshToken: "refresh-token"}
+	require.NoError(t, store.Save(context.Background(), token))
+
+	loaded, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token, loaded)
+}
+
+func TestFileTokenStoreError(t *testing.T) {
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token.json")
+		require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+
+		_, err := gokick.NewFileTokenStore(path).Load(context.Background())
+		require.EqualError(t, err, "failed to unmarshal token file: invalid character 'i' looking for beginning of value")
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "token.json")
+
+		err := gokick.NewFileTokenStore(path).Save(context.Background(), gokick.Token{})
+		require.ErrorContains(t, err, "failed to create token file")
+	})
+}
+
+func TestFileTokenStoreSuccess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := gokick.NewFileTokenStore(path)
+
+	_, err := store.Load(context.Background())
+	require.ErrorIs(t, err, gokick.ErrTokenNotFound)
+
+	token := gokick.Token{AccessToken: "access-token", RefreshToken: "refresh-token"}
+	require.NoError(t, store.Save(context.Background(), token))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := gokick.NewFileTokenStore(path).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token, loaded)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type (
@@ -58,9 +59,12 @@ func (f UserListFilter) ToQueryString() string {
 }
 
 func (c *Client) TokenIntrospect(ctx context.Context) (TokenIntrospectResponseWrapper, error) {
+	token := c.selectToken(ctx)
+
 	response, err := makeRequest[TokenIntrospectResponse](
 		ctx,
 		c,
+		Operation{Name: "TokenIntrospect"},
 		http.MethodPost,
 		"/public/v1/token/introspect",
 		http.StatusOK,
@@ -70,6 +74,10 @@ func (c *Client) TokenIntrospect(ctx context.Context) (TokenIntrospectResponseWr
 		return TokenIntrospectResponseWrapper{}, err
 	}
 
+	if token.kind == tokenKindUser && response.Result.Active && response.Result.Exp > 0 {
+		c.setUserAccessTokenExpiry(token.value, time.Unix(int64(response.Result.Exp), 0))
+	}
+
 	return TokenIntrospectResponseWrapper(response), nil
 }
 
@@ -77,6 +85,7 @@ func (c *Client) GetUsers(ctx context.Context, filter UserListFilter) (UsersResp
 	response, err := makeRequest[[]UserResponse](
 		ctx,
 		c,
+		Operation{Name: "GetUsers", Scopes: []Scope{ScopeUserRead}},
 		http.MethodGet,
 		fmt.Sprintf("/public/v1/users%s", filter.ToQueryString()),
 		http.StatusOK,
@@ -0,0 +1,146 @@
+package gokick
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Event is a webhook with the metadata of its delivery.
+type Event struct {
+	// ID is the message ID of the delivery, kept by KICK when it retries it.
+	ID string
+	// Name is the event type, and Subscription the same type when KnownSubscription, i.e. when it is known by
+	// this package (see UnknownEvent). Subscription is the zero SubscriptionName otherwise.
+	Name              string
+	Subscription      SubscriptionName
+	KnownSubscription bool
+	Version           string
+	// Timestamp is the time KICK sent the webhook, zero when unknown.
+	Timestamp time.Time
+	// Raw is the body of the webhook, as received.
+	Raw json.RawMessage
+	// Payload is the decoded body, e.g. a *ChatMessageEvent.
+	Payload interface{}
+}
+
+// eventKey holds the Event dispatched by a WebhookHandler.
+const eventKey contextKey = "webhook-event"
+
+// EventFromContext returns the Event a WebhookHandler passes to its callbacks, with the metadata of the delivery.
+func EventFromContext(ctx context.Context) (*Event, bool) {
+	event, ok := ctx.Value(eventKey).(*Event)
+	return event, ok
+}
+
+func newEvent(headers WebhookHeaders, body []byte) (*Event, error) {
+	name := headers.Name
+	if name == "" {
+		name = headers.Subscription.String()
+	}
+
+	payload, err := decodeEvent(name, headers.Version, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The headers may not come from ParseWebhookHeaders, so the subscription is read from the name.
+	subscription, subscriptionErr := NewSubscriptionName(name)
+
+	return &Event{
+		ID:                headers.MessageID,
+		Name:              name,
+		Subscription:      subscription,
+		KnownSubscription: subscriptionErr == nil,
+		Version:           headers.Version,
+		Timestamp:         headers.Timestamp,
+		Raw:               json.RawMessage(body),
+		Payload:           payload,
+	}, nil
+}
+
+// ErrUnexpectedEventType is returned by As and ParseEvent when an event is not of the requested type.
+var ErrUnexpectedEventType = errors.New("unexpected event type")
+
+// EventPayload is implemented by the payloads of the events known by this package, e.g. *ChatMessageEvent,
+// mapping each payload type to its SubscriptionName.
+type EventPayload interface {
+	SubscriptionName() SubscriptionName
+}
+
+func (*ChatMessageEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameChatMessage
+}
+
+func (*ChannelFollowEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameChannelFollow
+}
+
+func (*ChannelSubscriptionRenewalEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameChannelSubscriptionRenewal
+}
+
+func (*ChannelSubscriptionGiftsEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameChannelSubscriptionGifts
+}
+
+func (*ChannelSubscriptionCreatedEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameChannelSubscriptionCreated
+}
+
+func (*LivestreamStatusUpdatedEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameLivestreamStatusUpdated
+}
+
+func (*LivestreamMetadataUpdatedEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameLivestreamMetadataUpdated
+}
+
+func (*ModerationBannedEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameModerationBanned
+}
+
+func (*KicksGiftedEvent) SubscriptionName() SubscriptionName {
+	return SubscriptionNameKicksGifted
+}
+
+// As returns the payload of event, an *Event or a payload returned by ValidateAndParseEvent, as a T.
+// It fails with ErrUnexpectedEventType when the payload is not a T.
+func As[T any](event interface{}) (T, error) {
+	var zero T
+
+	payload := event
+	if envelope, ok := event.(*Event); ok && envelope != nil {
+		payload = envelope.Payload
+	}
+
+	typed, ok := payload.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: got %T, expected %T", ErrUnexpectedEventType, payload, zero)
+	}
+
+	return typed, nil
+}
+
+// ParseEvent returns the payload of event as a T, e.g. a *KicksGiftedEvent. It fails with ErrUnexpectedEventType
+// when event is of another type than the one of T, or of an unknown version of it.
+func ParseEvent[T EventPayload](event *Event) (T, error) {
+	var zero T
+
+	if event == nil {
+		return zero, errors.New("event cannot be nil")
+	}
+
+	expected := zero.SubscriptionName().String()
+	if event.Name != expected {
+		return zero, fmt.Errorf("%w: got %s, expected %s", ErrUnexpectedEventType, event.Name, expected)
+	}
+
+	if unknown, ok := event.Payload.(*UnknownEvent); ok {
+		return zero, fmt.Errorf("%w: unknown version %s of %s", ErrUnexpectedEventType, unknown.Version, expected)
+	}
+
+	return As[T](event)
+}
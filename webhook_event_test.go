@@ -0,0 +1,164 @@
+package gokick_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVerifiedEvent(t *testing.T, name string, version string, body string) *gokick.Event {
+	t.Helper()
+
+	event, err := (&gokick.EventVerifier{SkipSignatureValidation: true}).VerifyEvent(
+		context.Background(),
+		gokick.WebhookHeaders{Name: name, Version: version},
+		[]byte(body),
+	)
+	require.NoError(t, err)
+
+	return event
+}
+
+func TestAsError(t *testing.T) {
+	testCases := map[string]struct {
+		event    interface{}
+		expected string
+	}{
+		"other payload": {
+			event:    &gokick.ChatMessageEvent{},
+			expected: "unexpected event type: got *gokick.ChatMessageEvent, expected *gokick.KicksGiftedEvent",
+		},
+		"other envelope": {
+			event:    &gokick.Event{Payload: &gokick.ChannelFollowEvent{}},
+			expected: "unexpected event type: got *gokick.ChannelFollowEvent, expected *gokick.KicksGiftedEvent",
+		},
+		"nil": {
+			event:    nil,
+			expected: "unexpected event type: got <nil>, expected *gokick.KicksGiftedEvent",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := gokick.As[*gokick.KicksGiftedEvent](tc.event)
+			require.ErrorIs(t, err, gokick.ErrUnexpectedEventType)
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestAsSuccess(t *testing.T) {
+	t.Run("payload", func(t *testing.T) {
+		gift, err := gokick.As[*gokick.KicksGiftedEvent](&gokick.KicksGiftedEvent{CreatedAt: "now"})
+		require.NoError(t, err)
+		assert.Equal(t, "now", gift.CreatedAt)
+	})
+
+	t.Run("envelope", func(t *testing.T) {
+		event := newVerifiedEvent(t, "kicks.gifted", "1", `{"gift":{"amount":100}}`)
+
+		gift, err := gokick.As[*gokick.KicksGiftedEvent](event)
+		require.NoError(t, err)
+		assert.Equal(t, 100, gift.Gift.Amount)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		event := newVerifiedEvent(t, "channel.raided", "1", `{}`)
+
+		assert.False(t, event.KnownSubscription)
+
+		unknown, err := gokick.As[*gokick.UnknownEvent](event)
+		require.NoError(t, err)
+		assert.Equal(t, "channel.raided", unknown.Name)
+	})
+}
+
+func TestParseEventError(t *testing.T) {
+	t.Run("nil event", func(t *testing.T) {
+		_, err := gokick.ParseEvent[*gokick.KicksGiftedEvent](nil)
+		require.EqualError(t, err, "event cannot be nil")
+	})
+
+	t.Run("other event type", func(t *testing.T) {
+		event := newVerifiedEvent(t, "chat.message.sent", "1", `{}`)
+
+		_, err := gokick.ParseEvent[*gokick.KicksGiftedEvent](event)
+		require.ErrorIs(t, err, gokick.ErrUnexpectedEventType)
+		require.EqualError(t, err, "unexpected event type: got chat.message.sent, expected kicks.gifted")
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		event := newVerifiedEvent(t, "kicks.gifted", "2", `{}`)
+
+		_, err := gokick.ParseEvent[*gokick.KicksGiftedEvent](event)
+		require.ErrorIs(t, err, gokick.ErrUnexpectedEventType)
+		require.EqualError(t, err, "unexpected event type: unknown version 2 of kicks.gifted")
+	})
+}
+
+func TestParseEventSuccess(t *testing.T) {
+	testCases := map[string]struct {
+		name  string
+		parse func(*gokick.Event) (interface{}, error)
+	}{
+		"chat message": {
+			name:  "chat.message.sent",
+			parse: func(e *gokick.Event) (interface{}, error) { return gokick.ParseEvent[*gokick.ChatMessageEvent](e) },
+		},
+		"channel follow": {
+			name:  "channel.followed",
+			parse: func(e *gokick.Event) (interface{}, error) { return gokick.ParseEvent[*gokick.ChannelFollowEvent](e) },
+		},
+		"subscription renewal": {
+			name: "channel.subscription.renewal",
+			parse: func(e *gokick.Event) (interface{}, error) {
+				return gokick.ParseEvent[*gokick.ChannelSubscriptionRenewalEvent](e)
+			},
+		},
+		"subscription gifts": {
+			name: "channel.subscription.gifts",
+			parse: func(e *gokick.Event) (interface{}, error) {
+				return gokick.ParseEvent[*gokick.ChannelSubscriptionGiftsEvent](e)
+			},
+		},
+		"subscription created": {
+			name: "channel.subscription.new",
+			parse: func(e *gokick.Event) (interface{}, error) {
+				return gokick.ParseEvent[*gokick.ChannelSubscriptionCreatedEvent](e)
+			},
+		},
+		"livestream status": {
+			name: "livestream.status.updated",
+			parse: func(e *gokick.Event) (interface{}, error) {
+				return gokick.ParseEvent[*gokick.LivestreamStatusUpdatedEvent](e)
+			},
+		},
+		"livestream metadata": {
+			name: "livestream.metadata.updated",
+			parse: func(e *gokick.Event) (interface{}, error) {
+				return gokick.ParseEvent[*gokick.LivestreamMetadataUpdatedEvent](e)
+			},
+		},
+		"moderation banned": {
+			name:  "moderation.banned",
+			parse: func(e *gokick.Event) (interface{}, error) { return gokick.ParseEvent[*gokick.ModerationBannedEvent](e) },
+		},
+		"kicks gifted": {
+			name:  "kicks.gifted",
+			parse: func(e *gokick.Event) (interface{}, error) { return gokick.ParseEvent[*gokick.KicksGiftedEvent](e) },
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			event := newVerifiedEvent(t, tc.name, "1", `{}`)
+
+			payload, err := tc.parse(event)
+			require.NoError(t, err)
+			assert.Same(t, event.Payload, payload)
+		})
+	}
+}
@@ -1,17 +1,15 @@
 package gokick
 
 import (
-	"bytes"
+	"context"
 	"crypto"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
-	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 )
 
@@ -133,9 +131,8 @@ type KicksGiftedEvent struct {
 	CreatedAt string `json:"created_at"`
 }
 
-// I set it as public to be able to change it in tests.
-// It's not a good practice to do so, but it's the only way to do it for now.
-var DefaultEventPublicKey = `-----BEGIN PUBLIC KEY-----
+// KickEventPublicKey is the key KICK signed webhooks with when this package was released.
+const KickEventPublicKey = `-----BEGIN PUBLIC KEY-----
 MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAq/+l1WnlRrGSolDMA+A8
 6rAhMbQGmQ2SapVcGM3zq8ANXjnhDWocMqfWcTd95btDydITa10kDvHzw9WQOqp2
 MZI7ZyrfzJuz5nhTPCiJwTwnEtWft7nV14BYRDHvlfqPUaZ+1KR4OCaO/wWIk/rQ
@@ -145,73 +142,35 @@ BEbZ5jgB8s8ReQV8H+MkuffjdAj3ajDDX3DOJMIut1lBrUVD1AaSrGCKHooWoL2e
 twIDAQAB
 -----END PUBLIC KEY-----`
 
-// I set it to be able to tests without having to sign the events.
-// It's not a good practice to do so, but it's the only way to do it for now.
-// Do not override it in production !
+// DefaultEventPublicKey is the key verifying webhooks when EventVerifier.PublicKey is nil.
+//
+// Deprecated: overriding it changes the verification of the whole process, set EventVerifier.PublicKey instead.
+var DefaultEventPublicKey = KickEventPublicKey
+
+// SkipSignatureValidation disables the signature verification of the package-level functions, and of a
+// WebhookHandler without SetVerifier.
+//
+// Deprecated: it disables the verification for the whole process, use EventVerifier.SkipSignatureValidation
+// in tests instead. Do not set it in production!
 var SkipSignatureValidation = false
 
+// GetEventFromRequest verifies a webhook request and decodes its payload.
+// It uses the package-level defaults, see EventVerifier.ParseRequest to configure the verification.
 func GetEventFromRequest(request *http.Request) (interface{}, error) {
-	if request == nil {
-		return nil, errors.New("request cannot be nil")
-	}
-
-	subscriptionName, err := NewSubscriptionName(request.Header.Get("X-Event-Subscription"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse subscription name: %v", err)
-	}
-
-	version := request.Header.Get("X-Event-Version")
-	eventSignature := request.Header.Get("X-Event-Signature")
-	messageID := request.Header.Get("X-Event-Message-Id")
-	timestamp := request.Header.Get("X-Event-Timestamp")
-
-	body, err := io.ReadAll(request.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read body: %v", err)
-	}
-
-	return ValidateAndParseEvent(
-		subscriptionName,
-		version,
-		eventSignature,
-		messageID,
-		timestamp,
-		string(body),
-	)
+	return defaultEventVerifier().ParseRequest(request)
 }
 
+// ValidateEvent reports whether the signature of a webhook is valid.
+// It uses the package-level defaults, see EventVerifier.Verify to configure the verification.
 func ValidateEvent(
 	header http.Header,
 	body []byte,
 ) bool {
-	if !SkipSignatureValidation {
-		payload := struct {
-			signature string
-			messageID string
-			timestamp string
-		}{
-			signature: header.Get("Kick-Event-Signature"),
-			messageID: header.Get("Kick-Event-Message-Id"),
-			timestamp: header.Get("Kick-Event-Message-Timestamp"),
-		}
-
-		signature := bytes.Join([][]byte{
-			[]byte(payload.messageID),
-			[]byte(payload.timestamp),
-			body,
-		}, []byte("."))
-
-		publicKey, _ := parsePublicKey([]byte(DefaultEventPublicKey))
-
-		err := verifyEventValidity(&publicKey, signature, []byte(payload.signature))
-		if err != nil {
-			return false
-		}
-	}
-
-	return true
+	return defaultEventVerifier().Verify(context.Background(), header, body) == nil
 }
 
+// ValidateAndParseEvent verifies a webhook from the values of its headers and decodes its payload.
+// It uses the package-level defaults, see EventVerifier.ValidateAndParse to configure the verification.
 func ValidateAndParseEvent(
 	subscriptionName SubscriptionName,
 	version string,
@@ -220,33 +179,15 @@ func ValidateAndParseEvent(
 	timestamp string,
 	body string,
 ) (interface{}, error) {
-	if !SkipSignatureValidation {
-		signature := []byte(fmt.Sprintf("%s.%s.%s", messageID, timestamp, body))
-
-		publicKey, err := parsePublicKey([]byte(DefaultEventPublicKey))
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse public key: %v", err)
-		}
-
-		err = verifyEventValidity(&publicKey, signature, []byte(eventSignature))
-		if err != nil {
-			return nil, fmt.Errorf("failed to verify event validity: %v", err)
-		}
-	}
-
-	var event interface{}
-	if versionConstructor, ok := eventConstructors[subscriptionName]; ok {
-		if constructor, ok := versionConstructor[version]; ok {
-			event = constructor()
-		}
-	}
-
-	err := json.Unmarshal([]byte(body), &event)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal event: %v", err)
-	}
-
-	return event, nil
+	return defaultEventVerifier().ValidateAndParse(
+		context.Background(),
+		subscriptionName,
+		version,
+		eventSignature,
+		messageID,
+		timestamp,
+		[]byte(body),
+	)
 }
 
 func parsePublicKey(key []byte) (rsa.PublicKey, error) {
@@ -290,35 +231,3 @@ func verifyEventValidity(publicKey *rsa.PublicKey, body []byte, signature []byte
 
 	return nil
 }
-
-type eventConstructor func() interface{}
-
-var eventConstructors = map[SubscriptionName]map[string]eventConstructor{
-	SubscriptionNameChatMessage: {
-		"1": func() interface{} { return new(ChatMessageEvent) },
-	},
-	SubscriptionNameChannelFollow: {
-		"1": func() interface{} { return new(ChannelFollowEvent) },
-	},
-	SubscriptionNameChannelSubscriptionRenewal: {
-		"1": func() interface{} { return new(ChannelSubscriptionRenewalEvent) },
-	},
-	SubscriptionNameChannelSubscriptionGifts: {
-		"1": func() interface{} { return new(ChannelSubscriptionGiftsEvent) },
-	},
-	SubscriptionNameChannelSubscriptionCreated: {
-		"1": func() interface{} { return new(ChannelSubscriptionCreatedEvent) },
-	},
-	SubscriptionNameLivestreamStatusUpdated: {
-		"1": func() interface{} { return new(LivestreamStatusUpdatedEvent) },
-	},
-	SubscriptionNameLivestreamMetadataUpdated: {
-		"1": func() interface{} { return new(LivestreamMetadataUpdatedEvent) },
-	},
-	SubscriptionNameModerationBanned: {
-		"1": func() interface{} { return new(ModerationBannedEvent) },
-	},
-	SubscriptionNameKicksGifted: {
-		"1": func() interface{} { return new(KicksGiftedEvent) },
-	},
-}
@@ -17,13 +17,12 @@ func TestGetEventFromRequestError(t *testing.T) {
 		require.EqualError(t, err, "request cannot be nil")
 	})
 
-	t.Run("invalid subscription name", func(t *testing.T) {
+	t.Run("missing subscription name", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "https://domain.tld", strings.NewReader(""))
 		require.NoError(t, err)
-		req.Header.Set("X-Event-Subscription", "invalid")
 
 		_, err = gokick.GetEventFromRequest(req)
-		require.EqualError(t, err, "failed to parse subscription name: unknown name: invalid")
+		require.EqualError(t, err, "failed to parse subscription name: missing event type")
 	})
 
 	t.Run("invalid body", func(t *testing.T) {
@@ -61,6 +60,23 @@ func TestGetEventFromRequestSuccess(t *testing.T) {
 	assert.IsType(t, &gokick.ChatMessageEvent{}, event)
 }
 
+func TestGetEventFromRequestKickHeaders(t *testing.T) {
+	skipSignatureValidation(t)
+
+	req, err := http.NewRequest("POST", "https://domain.tld", strings.NewReader("{}"))
+	require.NoError(t, err)
+	req.Header.Set("Kick-Event-Type", "channel.followed")
+	req.Header.Set("Kick-Event-Version", "1")
+	req.Header.Set("Kick-Event-Signature", "signature")
+	req.Header.Set("Kick-Event-Message-Id", "message ID")
+	req.Header.Set("Kick-Event-Message-Timestamp", "2025-02-21T23:23:36Z")
+
+	event, err := gokick.GetEventFromRequest(req)
+	require.NoError(t, err)
+	assert.IsType(t, &gokick.ChannelFollowEvent{}, event)
+}
+
+//nolint:staticcheck // exercises the deprecated package-level defaults
 func TestValidateEventError(t *testing.T) {
 	previousKey := gokick.DefaultEventPublicKey
 	t.Cleanup(func() { gokick.DefaultEventPublicKey = previousKey })
@@ -92,6 +108,7 @@ func TestValidateEventSuccess(t *testing.T) {
 	assert.True(t, valid)
 }
 
+//nolint:staticcheck // exercises the deprecated package-level defaults
 func TestValidateAndParseEventError(t *testing.T) {
 	t.Run("failed to decode public key", func(t *testing.T) {
 		previousKey := gokick.DefaultEventPublicKey
@@ -288,9 +305,10 @@ func TestValidateAndParseEventSuccess(t *testing.T) {
 				`"emotes":null}`,
 		)
 		require.NoError(t, err)
-		assert.IsType(t, map[string]interface{}{}, event)
-		assert.Equal(t, "bb9832e4-e865-48f4-a0c3-392f78bf3b1a", event.(map[string]interface{})["message_id"])
-		assert.Equal(t, "coucou", event.(map[string]interface{})["content"])
+		require.IsType(t, &gokick.UnknownEvent{}, event)
+		assert.Equal(t, "chat.message.sent", event.(*gokick.UnknownEvent).Name)
+		assert.Equal(t, "-1", event.(*gokick.UnknownEvent).Version)
+		assert.Contains(t, string(event.(*gokick.UnknownEvent).Raw), `"content":"coucou"`)
 	})
 
 	t.Run("all events with version", func(t *testing.T) {
@@ -371,6 +389,7 @@ func (r faultyReader) Read(p []byte) (n int, err error) {
 	return 0, errors.New("read error")
 }
 
+//nolint:staticcheck // exercises the deprecated package-level defaults
 func skipSignatureValidation(t *testing.T) {
 	t.Helper()
 
@@ -0,0 +1,297 @@
+package gokick
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type (
+	onChatMessageCallback                func(ctx context.Context, event *ChatMessageEvent)
+	onChannelFollowCallback              func(ctx context.Context, event *ChannelFollowEvent)
+	onChannelSubscriptionRenewalCallback func(ctx context.Context, event *ChannelSubscriptionRenewalEvent)
+	onChannelSubscriptionGiftsCallback   func(ctx context.Context, event *ChannelSubscriptionGiftsEvent)
+	onChannelSubscriptionCreatedCallback func(ctx context.Context, event *ChannelSubscriptionCreatedEvent)
+	onLivestreamStatusUpdatedCallback    func(ctx context.Context, event *LivestreamStatusUpdatedEvent)
+	onLivestreamMetadataUpdatedCallback  func(ctx context.Context, event *LivestreamMetadataUpdatedEvent)
+	onModerationBannedCallback           func(ctx context.Context, event *ModerationBannedEvent)
+	onKicksGiftedCallback                func(ctx context.Context, event *KicksGiftedEvent)
+	onUnknownEventCallback               func(ctx context.Context, event *UnknownEvent)
+	onEventCallback                      func(ctx context.Context, event *Event)
+	onWebhookErrorCallback               func(request *http.Request, err error)
+)
+
+var errMethodNotAllowed = errors.New("method not allowed")
+
+type webhookCallbacks struct {
+	onChatMessage                onChatMessageCallback
+	onChannelFollow              onChannelFollowCallback
+	onChannelSubscriptionRenewal onChannelSubscriptionRenewalCallback
+	onChannelSubscriptionGifts   onChannelSubscriptionGiftsCallback
+	onChannelSubscriptionCreated onChannelSubscriptionCreatedCallback
+	onLivestreamStatusUpdated    onLivestreamStatusUpdatedCallback
+	onLivestreamMetadataUpdated  onLivestreamMetadataUpdatedCallback
+	onModerationBanned           onModerationBannedCallback
+	onKicksGifted                onKicksGiftedCallback
+	onUnknownEvent               onUnknownEventCallback
+	onEvent                      onEventCallback
+	onError                      onWebhookErrorCallback
+}
+
+// WebhookHandler is an http.Handler receiving KICK webhooks.
+// It verifies the signature of each request, decodes the payload and dispatches it
+// to the callback registered for its type, with the Event in the context (see EventFromContext).
+// It answers 400 when the payload cannot be decoded, 401 when the signature is invalid
+// (or the event is too old, see SetVerifier) and 200 otherwise.
+type WebhookHandler struct {
+	mu        sync.RWMutex
+	callbacks webhookCallbacks
+	verifier  *EventVerifier
+}
+
+// NewWebhookHandler returns a handler verifying webhooks with the package-level defaults, see SetVerifier.
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{verifier: defaultEventVerifier()}
+}
+
+func (h *WebhookHandler) OnChatMessage(callback onChatMessageCallback) {
+	h.mu.Lock()
+	h.callbacks.onChatMessage = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnChannelFollow(callback onChannelFollowCallback) {
+	h.mu.Lock()
+	h.callbacks.onChannelFollow = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnChannelSubscriptionRenewal(callback onChannelSubscriptionRenewalCallback) {
+	h.mu.Lock()
+	h.callbacks.onChannelSubscriptionRenewal = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnChannelSubscriptionGifts(callback onChannelSubscriptionGiftsCallback) {
+	h.mu.Lock()
+	h.callbacks.onChannelSubscriptionGifts = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnChannelSubscriptionCreated(callback onChannelSubscriptionCreatedCallback) {
+	h.mu.Lock()
+	h.callbacks.onChannelSubscriptionCreated = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnLivestreamStatusUpdated(callback onLivestreamStatusUpdatedCallback) {
+	h.mu.Lock()
+	h.callbacks.onLivestreamStatusUpdated = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnLivestreamMetadataUpdated(callback onLivestreamMetadataUpdatedCallback) {
+	h.mu.Lock()
+	h.callbacks.onLivestreamMetadataUpdated = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnModerationBanned(callback onModerationBannedCallback) {
+	h.mu.Lock()
+	h.callbacks.onModerationBanned = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) OnKicksGifted(callback onKicksGiftedCallback) {
+	h.mu.Lock()
+	h.callbacks.onKicksGifted = callback
+	h.mu.Unlock()
+}
+
+// OnUnknownEvent registers a callback invoked for the events whose type, or version, is not registered
+// (see RegisterEventType).
+func (h *WebhookHandler) OnUnknownEvent(callback onUnknownEventCallback) {
+	h.mu.Lock()
+	h.callbacks.onUnknownEvent = callback
+	h.mu.Unlock()
+}
+
+// OnEvent registers a callback invoked for every event, before the callback registered for its type.
+// It receives the events decoded into a type registered with RegisterEventType too.
+func (h *WebhookHandler) OnEvent(callback onEventCallback) {
+	h.mu.Lock()
+	h.callbacks.onEvent = callback
+	h.mu.Unlock()
+}
+
+// SetVerifier sets the verifier of the webhooks. Events outside the tolerance window of its ReplayGuard are
+// rejected with a 401, and events already received are acknowledged with a 200 without being dispatched.
+func (h *WebhookHandler) SetVerifier(verifier *EventVerifier) {
+	h.mu.Lock()
+	h.verifier = verifier
+	h.mu.Unlock()
+}
+
+// SetReplayGuard sets the ReplayGuard of the verifier of the handler.
+func (h *WebhookHandler) SetReplayGuard(guard *ReplayGuard) {
+	h.mu.Lock()
+	verifier := *h.verifierLocked()
+	verifier.ReplayGuard = guard
+	h.verifier = &verifier
+	h.mu.Unlock()
+}
+
+// SetPublicKeyProvider sets the PublicKey of the verifier of the handler.
+func (h *WebhookHandler) SetPublicKeyProvider(provider PublicKeyProvider) {
+	h.mu.Lock()
+	verifier := *h.verifierLocked()
+	verifier.PublicKey = provider
+	h.verifier = &verifier
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) verifierLocked() *EventVerifier {
+	if h.verifier == nil {
+		return defaultEventVerifier()
+	}
+
+	return h.verifier
+}
+
+// OnError registers a callback invoked whenever a request is rejected, or ignored as a replay.
+func (h *WebhookHandler) OnError(callback onWebhookErrorCallback) {
+	h.mu.Lock()
+	h.callbacks.onError = callback
+	h.mu.Unlock()
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.reject(w, r, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+
+	headers, err := ParseWebhookHeaders(r.Header)
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, fmt.Errorf("failed to read body: %w", err))
+		return
+	}
+
+	h.mu.RLock()
+	verifier := h.verifierLocked()
+	h.mu.RUnlock()
+
+	err = verifier.verifyHeaders(r.Context(), headers, body)
+	if errors.Is(err, ErrEventReplayed) {
+		h.reportError(r, err)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if err != nil {
+		h.reject(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	event, err := newEvent(headers, body)
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, errors.Join(err, verifier.forget(r.Context(), headers.MessageID)))
+		return
+	}
+
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		// The delivery fails and is retried by KICK, which must not be ignored as a replay.
+		err = verifier.forget(r.Context(), headers.MessageID)
+		if err != nil {
+			h.reportError(r, err)
+		}
+		panic(recovered)
+	}()
+
+	h.dispatch(context.WithValue(r.Context(), eventKey, event), event)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *WebhookHandler) reject(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	h.reportError(r, err)
+	http.Error(w, http.StatusText(statusCode), statusCode)
+}
+
+func (h *WebhookHandler) reportError(r *http.Request, err error) {
+	h.mu.RLock()
+	callback := h.callbacks.onError
+	h.mu.RUnlock()
+
+	if callback != nil {
+		callback(r, err)
+	}
+}
+
+//nolint:gocyclo // one case per event type
+func (h *WebhookHandler) dispatch(ctx context.Context, event *Event) {
+	h.mu.RLock()
+	callbacks := h.callbacks
+	h.mu.RUnlock()
+
+	if callbacks.onEvent != nil {
+		callbacks.onEvent(ctx, event)
+	}
+
+	switch e := event.Payload.(type) {
+	case *ChatMessageEvent:
+		if callbacks.onChatMessage != nil {
+			callbacks.onChatMessage(ctx, e)
+		}
+	case *ChannelFollowEvent:
+		if callbacks.onChannelFollow != nil {
+			callbacks.onChannelFollow(ctx, e)
+		}
+	case *ChannelSubscriptionRenewalEvent:
+		if callbacks.onChannelSubscriptionRenewal != nil {
+			callbacks.onChannelSubscriptionRenewal(ctx, e)
+		}
+	case *ChannelSubscriptionGiftsEvent:
+		if callbacks.onChannelSubscriptionGifts != nil {
+			callbacks.onChannelSubscriptionGifts(ctx, e)
+		}
+	case *ChannelSubscriptionCreatedEvent:
+		if callbacks.onChannelSubscriptionCreated != nil {
+			callbacks.onChannelSubscriptionCreated(ctx, e)
+		}
+	case *LivestreamStatusUpdatedEvent:
+		if callbacks.onLivestreamStatusUpdated != nil {
+			callbacks.onLivestreamStatusUpdated(ctx, e)
+		}
+	case *LivestreamMetadataUpdatedEvent:
+		if callbacks.onLivestreamMetadataUpdated != nil {
+			callbacks.onLivestreamMetadataUpdated(ctx, e)
+		}
+	case *ModerationBannedEvent:
+		if callbacks.onModerationBanned != nil {
+			callbacks.onModerationBanned(ctx, e)
+		}
+	case *KicksGiftedEvent:
+		if callbacks.onKicksGifted != nil {
+			callbacks.onKicksGifted(ctx, e)
+		}
+	case *UnknownEvent:
+		if callbacks.onUnknownEvent != nil {
+			callbacks.onUnknownEvent(ctx, e)
+		}
+	}
+}
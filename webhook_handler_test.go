@@ -0,0 +1,241 @@
+package gokick_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookRequest(t *testing.T, eventType string, body string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("Kick-Event-Type", eventType)
+	req.Header.Set("Kick-Event-Version", "1")
+	req.Header.Set("Kick-Event-Signature", "b3NzMTE3")
+	req.Header.Set("Kick-Event-Message-Id", "message ID")
+	req.Header.Set("Kick-Event-Message-Timestamp", "2025-02-21T23:23:36Z")
+
+	return req
+}
+
+func TestWebhookHandlerError(t *testing.T) {
+	t.Run("invalid method", func(t *testing.T) {
+		handler := gokick.NewWebhookHandler()
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/webhook", http.NoBody))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+	})
+
+	t.Run("missing subscription name", func(t *testing.T) {
+		handler := gokick.NewWebhookHandler()
+
+		var handlerErr error
+		handler.OnError(func(_ *http.Request, err error) { handlerErr = err })
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "", "{}"))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		require.EqualError(t, handlerErr, "failed to parse subscription name: missing event type")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		handler := gokick.NewWebhookHandler()
+
+		req := newWebhookRequest(t, "chat.message.sent", "")
+		req.Body = faultyReadCloser{}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		handler := gokick.NewWebhookHandler()
+
+		var handlerErr error
+		handler.OnError(func(_ *http.Request, err error) { handlerErr = err })
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "chat.message.sent", "{}"))
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		require.EqualError(t, handlerErr, "failed to verify event validity: failed to verify signature: crypto/rsa: verification error")
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		handler := gokick.NewWebhookHandler()
+
+		called := false
+		handler.OnChatMessage(func(context.Context, *gokick.ChatMessageEvent) { called = true })
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "chat.message.sent", "invalid JSON"))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.False(t, called)
+	})
+}
+
+func TestWebhookHandlerSuccess(t *testing.T) {
+	t.Run("dispatch to typed callbacks", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		received := make(map[string]interface{})
+
+		handler := gokick.NewWebhookHandler()
+		handler.OnChatMessage(func(_ context.Context, e *gokick.ChatMessageEvent) { received["chat.message.sent"] = e })
+		handler.OnChannelFollow(func(_ context.Context, e *gokick.ChannelFollowEvent) { received["channel.followed"] = e })
+		handler.OnChannelSubscriptionRenewal(func(_ context.Context, e *gokick.ChannelSubscriptionRenewalEvent) {
+			received["channel.subscription.renewal"] = e
+		})
+		handler.OnChannelSubscriptionGifts(func(_ context.Context, e *gokick.ChannelSubscriptionGiftsEvent) {
+			received["channel.subscription.gifts"] = e
+		})
+		handler.OnChannelSubscriptionCreated(func(_ context.Context, e *gokick.ChannelSubscriptionCreatedEvent) {
+			received["channel.subscription.new"] = e
+		})
+		handler.OnLivestreamStatusUpdated(func(_ context.Context, e *gokick.LivestreamStatusUpdatedEvent) {
+			received["livestream.status.updated"] = e
+		})
+		handler.OnLivestreamMetadataUpdated(func(_ context.Context, e *gokick.LivestreamMetadataUpdatedEvent) {
+			received["livestream.metadata.updated"] = e
+		})
+		handler.OnModerationBanned(func(_ context.Context, e *gokick.ModerationBannedEvent) { received["moderation.banned"] = e })
+		handler.OnKicksGifted(func(_ context.Context, e *gokick.KicksGiftedEvent) { received["kicks.gifted"] = e })
+
+		testCases := map[string]interface{}{
+			"chat.message.sent":            &gokick.ChatMessageEvent{},
+			"channel.followed":             &gokick.ChannelFollowEvent{},
+			"channel.subscription.renewal": &gokick.ChannelSubscriptionRenewalEvent{},
+			"channel.subscription.gifts":   &gokick.ChannelSubscriptionGiftsEvent{},
+			"channel.subscription.new":     &gokick.ChannelSubscriptionCreatedEvent{},
+			"livestream.status.updated":    &gokick.LivestreamStatusUpdatedEvent{},
+			"livestream.metadata.updated":  &gokick.LivestreamMetadataUpdatedEvent{},
+			"moderation.banned":            &gokick.ModerationBannedEvent{},
+			"kicks.gifted":                 &gokick.KicksGiftedEvent{},
+		}
+
+		for eventType, expectedType := range testCases {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, newWebhookRequest(t, eventType, "{}"))
+
+			assert.Equal(t, http.StatusOK, recorder.Code, eventType)
+			assert.IsType(t, expectedType, received[eventType], eventType)
+		}
+	})
+
+	t.Run("payload content", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		var event *gokick.KicksGiftedEvent
+
+		handler := gokick.NewWebhookHandler()
+		handler.OnKicksGifted(func(_ context.Context, e *gokick.KicksGiftedEvent) { event = e })
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "kicks.gifted", `{"gift":{"amount":100,"name":"Full Send"}}`))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		require.NotNil(t, event)
+		assert.Equal(t, 100, event.Gift.Amount)
+		assert.Equal(t, "Full Send", event.Gift.Name)
+	})
+
+	t.Run("event in context", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		var event *gokick.Event
+
+		handler := gokick.NewWebhookHandler()
+		handler.OnChannelFollow(func(ctx context.Context, _ *gokick.ChannelFollowEvent) {
+			event, _ = gokick.EventFromContext(ctx)
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.followed", "{}"))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		require.NotNil(t, event)
+		assert.Equal(t, "message ID", event.ID)
+		assert.Equal(t, gokick.SubscriptionNameChannelFollow, event.Subscription)
+		assert.Equal(t, "1", event.Version)
+		assert.Equal(t, json.RawMessage("{}"), event.Raw)
+		assert.IsType(t, &gokick.ChannelFollowEvent{}, event.Payload)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		var (
+			unknown  *gokick.UnknownEvent
+			received []string
+		)
+
+		handler := gokick.NewWebhookHandler()
+		handler.OnEvent(func(_ context.Context, e *gokick.Event) { received = append(received, e.Name) })
+		handler.OnUnknownEvent(func(_ context.Context, e *gokick.UnknownEvent) { unknown = e })
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.raided", `{"viewers":10}`))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		require.NotNil(t, unknown)
+		assert.Equal(t, "channel.raided", unknown.Name)
+		assert.Equal(t, "1", unknown.Version)
+		assert.JSONEq(t, `{"viewers":10}`, string(unknown.Raw))
+		assert.Equal(t, []string{"channel.raided"}, received)
+	})
+
+	t.Run("legacy headers", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		var event *gokick.ChannelFollowEvent
+
+		handler := gokick.NewWebhookHandler()
+		handler.OnChannelFollow(func(_ context.Context, e *gokick.ChannelFollowEvent) { event = e })
+
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}"))
+		req.Header.Set("X-Event-Subscription", "channel.followed")
+		req.Header.Set("X-Event-Version", "1")
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotNil(t, event)
+	})
+
+	t.Run("without callback", func(t *testing.T) {
+		skipSignatureValidation(t)
+
+		handler := gokick.NewWebhookHandler()
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.followed", "{}"))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+type faultyReadCloser struct {
+	faultyReader
+}
+
+func (faultyReadCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,94 @@
+package gokick
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookHeaders are the headers KICK sends with a webhook.
+type WebhookHeaders struct {
+	// Name is the event type, from Kick-Event-Type.
+	Name string
+	// Subscription is the event type when KnownSubscription, and the zero SubscriptionName otherwise: check
+	// KnownSubscription before switching on it.
+	Subscription SubscriptionName
+	// KnownSubscription reports whether Name is known by this package (see UnknownEvent).
+	KnownSubscription bool
+	// Version is the version of the payload, from Kick-Event-Version.
+	Version string
+	// MessageID identifies the event, and is kept by KICK when it retries a delivery.
+	MessageID string
+	// SubscriptionID is the event subscription that triggered the webhook.
+	SubscriptionID string
+	// Timestamp is the time KICK sent the webhook, zero when the header is missing.
+	Timestamp time.Time
+	// RawTimestamp is Kick-Event-Message-Timestamp as sent, which is part of the signed payload.
+	RawTimestamp string
+	// Signature is the base64 signature of the webhook.
+	Signature string
+}
+
+var errMissingEventType = errors.New("failed to parse subscription name: missing event type")
+
+// webhookHeader is the name of a header, and the name used by the first webhooks KICK sent.
+type webhookHeader struct {
+	name       string
+	legacyName string
+}
+
+var (
+	subscriptionHeader   = webhookHeader{name: "Kick-Event-Type", legacyName: "X-Event-Subscription"}
+	versionHeader        = webhookHeader{name: "Kick-Event-Version", legacyName: "X-Event-Version"}
+	messageIDHeader      = webhookHeader{name: "Kick-Event-Message-Id", legacyName: "X-Event-Message-Id"}
+	subscriptionIDHeader = webhookHeader{name: "Kick-Event-Subscription-Id", legacyName: "X-Event-Subscription-Id"}
+	timestampHeader      = webhookHeader{name: "Kick-Event-Message-Timestamp", legacyName: "X-Event-Timestamp"}
+	signatureHeader      = webhookHeader{name: "Kick-Event-Signature", legacyName: "X-Event-Signature"}
+)
+
+func (h webhookHeader) get(header http.Header) string {
+	if value := header.Get(h.name); value != "" {
+		return value
+	}
+
+	return header.Get(h.legacyName)
+}
+
+// ParseWebhookHeaders reads the Kick-Event-* headers of a webhook, falling back to the X-Event-* headers
+// of the first webhooks KICK sent. It fails when the event type is missing or the timestamp is malformed.
+func ParseWebhookHeaders(header http.Header) (WebhookHeaders, error) {
+	headers := readWebhookHeaders(header)
+
+	headers.Name = subscriptionHeader.get(header)
+	if headers.Name == "" {
+		return WebhookHeaders{}, errMissingEventType
+	}
+
+	subscriptionName, err := NewSubscriptionName(headers.Name)
+	if err == nil {
+		headers.Subscription = subscriptionName
+		headers.KnownSubscription = true
+	}
+
+	if headers.RawTimestamp != "" {
+		headers.Timestamp, err = time.Parse(time.RFC3339, headers.RawTimestamp)
+		if err != nil {
+			return WebhookHeaders{}, fmt.Errorf("failed to parse event timestamp: %w", err)
+		}
+	}
+
+	return headers, nil
+}
+
+// readWebhookHeaders reads the headers as sent, leaving Subscription and Timestamp unset. It is enough to verify
+// a signature, which does not depend on the event type.
+func readWebhookHeaders(header http.Header) WebhookHeaders {
+	return WebhookHeaders{
+		Version:        versionHeader.get(header),
+		MessageID:      messageIDHeader.get(header),
+		SubscriptionID: subscriptionIDHeader.get(header),
+		RawTimestamp:   timestampHeader.get(header),
+		Signature:      signatureHeader.get(header),
+	}
+}
@@ -0,0 +1,130 @@
+package gokick_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookHeadersError(t *testing.T) {
+	testCases := map[string]struct {
+		headers  map[string]string
+		expected string
+	}{
+		"missing subscription name": {
+			headers:  map[string]string{},
+			expected: "failed to parse subscription name: missing event type",
+		},
+		"invalid timestamp": {
+			headers: map[string]string{
+				"Kick-Event-Type":              "chat.message.sent",
+				"Kick-Event-Message-Timestamp": "yesterday",
+			},
+			expected: `failed to parse event timestamp: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tc.headers {
+				header.Set(key, value)
+			}
+
+			_, err := gokick.ParseWebhookHeaders(header)
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestParseWebhookHeadersSuccess(t *testing.T) {
+	expected := gokick.WebhookHeaders{
+		Name:              "chat.message.sent",
+		Subscription:      gokick.SubscriptionNameChatMessage,
+		KnownSubscription: true,
+		Version:           "1",
+		MessageID:         "01JMND5PSxxxxxx",
+		SubscriptionID:    "01JMN13xxxxxx",
+		Timestamp:         webhookTime,
+		RawTimestamp:      "2025-02-21T23:23:36Z",
+		Signature:         "signature",
+	}
+
+	testCases := map[string]struct {
+		headers  map[string]string
+		expected gokick.WebhookHeaders
+	}{
+		"kick headers": {
+			headers: map[string]string{
+				"Kick-Event-Type":              "chat.message.sent",
+				"Kick-Event-Version":           "1",
+				"Kick-Event-Message-Id":        "01JMND5PSxxxxxx",
+				"Kick-Event-Subscription-Id":   "01JMN13xxxxxx",
+				"Kick-Event-Message-Timestamp": "2025-02-21T23:23:36Z",
+				"Kick-Event-Signature":         "signature",
+			},
+			expected: expected,
+		},
+		"legacy headers": {
+			headers: map[string]string{
+				"X-Event-Subscription":    "chat.message.sent",
+				"X-Event-Version":         "1",
+				"X-Event-Message-Id":      "01JMND5PSxxxxxx",
+				"X-Event-Subscription-Id": "01JMN13xxxxxx",
+				"X-Event-Timestamp":       "2025-02-21T23:23:36Z",
+				"X-Event-Signature":       "signature",
+			},
+			expected: expected,
+		},
+		"kick headers take precedence": {
+			headers: map[string]string{
+				"Kick-Event-Type":      "channel.followed",
+				"X-Event-Subscription": "chat.message.sent",
+				"Kick-Event-Signature": "signature",
+				"X-Event-Signature":    "legacy signature",
+			},
+			expected: gokick.WebhookHeaders{
+				Name:              "channel.followed",
+				Subscription:      gokick.SubscriptionNameChannelFollow,
+				KnownSubscription: true,
+				Signature:         "signature",
+			},
+		},
+		"unknown subscription name": {
+			headers:  map[string]string{"Kick-Event-Type": "channel.raided"},
+			expected: gokick.WebhookHeaders{Name: "channel.raided"},
+		},
+		"without timestamp": {
+			headers: map[string]string{"Kick-Event-Type": "chat.message.sent"},
+			expected: gokick.WebhookHeaders{
+				Name:              "chat.message.sent",
+				Subscription:      gokick.SubscriptionNameChatMessage,
+				KnownSubscription: true,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tc.headers {
+				header.Set(key, value)
+			}
+
+			headers, err := gokick.ParseWebhookHeaders(header)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.Name, headers.Name)
+			assert.Equal(t, tc.expected.Subscription, headers.Subscription)
+			assert.Equal(t, tc.expected.KnownSubscription, headers.KnownSubscription)
+			assert.Equal(t, tc.expected.Version, headers.Version)
+			assert.Equal(t, tc.expected.MessageID, headers.MessageID)
+			assert.Equal(t, tc.expected.SubscriptionID, headers.SubscriptionID)
+			assert.True(t, tc.expected.Timestamp.Equal(headers.Timestamp))
+			assert.Equal(t, tc.expected.RawTimestamp, headers.RawTimestamp)
+			assert.Equal(t, tc.expected.Signature, headers.Signature)
+		})
+	}
+}
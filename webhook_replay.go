@@ -0,0 +1,206 @@
+package gokick
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReplayTolerance   = 5 * time.Minute
+	defaultSeenStoreCapacity = 10000
+)
+
+var (
+	// ErrEventTooOld is returned for an event whose timestamp is outside the tolerance window.
+	ErrEventTooOld = errors.New("event timestamp outside the tolerance window")
+	// ErrEventReplayed is returned for an event whose message ID has already been received.
+	ErrEventReplayed = errors.New("event already received")
+)
+
+// SeenStore records the message IDs of the webhooks already received.
+type SeenStore interface {
+	// MarkSeen records messageID for ttl, and reports whether it was already recorded.
+	MarkSeen(ctx context.Context, messageID string, ttl time.Duration) (bool, error)
+	// Forget removes messageID, so it is not reported as recorded anymore.
+	Forget(ctx context.Context, messageID string) error
+}
+
+// ReplayGuard protects webhook receivers against replayed deliveries: it rejects the events whose timestamp is
+// not within Tolerance of the current time, and the events whose message ID has already been seen.
+// KICK retries failed deliveries with the same message ID, so it also prevents processing an event twice; the
+// message ID of an event which could not be handled must be forgotten (see Forget) for its retry to be accepted.
+type ReplayGuard struct {
+	// Tolerance is the maximum difference between the timestamp of an event and the current time, 5 minutes when
+	// not positive.
+	Tolerance time.Duration
+	// Store records the message IDs, deduplication is disabled when nil.
+	Store SeenStore
+	// Now returns the current time, time.Now when nil.
+	Now func() time.Time
+}
+
+// NewReplayGuard returns a guard with a 5 minutes tolerance and an in-memory store of the last
+// 10000 message IDs.
+func NewReplayGuard() *ReplayGuard {
+	return &ReplayGuard{
+		Tolerance: defaultReplayTolerance,
+		Store:     NewMemorySeenStore(defaultSeenStoreCapacity),
+	}
+}
+
+// Check verifies the timestamp of an event (RFC 3339) and records its message ID.
+// It must be called after the signature has been verified, so forged requests cannot fill the store.
+func (g *ReplayGuard) Check(ctx context.Context, messageID, timestamp string) error {
+	return g.check(ctx, messageID, timestamp, g.now())
+}
+
+func (g *ReplayGuard) check(ctx context.Context, messageID, timestamp string, now time.Time) error {
+	sentAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to parse event timestamp: %w", err)
+	}
+
+	tolerance := g.tolerance()
+
+	age := now.Sub(sentAt)
+	if age > tolerance || age < -tolerance {
+		return ErrEventTooOld
+	}
+
+	if g.Store == nil {
+		return nil
+	}
+
+	// The message ID only needs to be remembered while its timestamp is accepted.
+	seen, err := g.Store.MarkSeen(ctx, messageID, 2*tolerance)
+	if err != nil {
+		return fmt.Errorf("failed to record event message ID: %w", err)
+	}
+
+	if seen {
+		return ErrEventReplayed
+	}
+
+	return nil
+}
+
+// Forget forgets the message ID of an event accepted by Check, e.g. when it could not be handled, so the retry of
+// KICK is not rejected as a replay.
+func (g *ReplayGuard) Forget(ctx context.Context, messageID string) error {
+	if g.Store == nil {
+		return nil
+	}
+
+	err := g.Store.Forget(ctx, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to forget event message ID: %w", err)
+	}
+
+	return nil
+}
+
+func (g *ReplayGuard) tolerance() time.Duration {
+	if g.Tolerance <= 0 {
+		return defaultReplayTolerance
+	}
+
+	return g.Tolerance
+}
+
+func (g *ReplayGuard) now() time.Time {
+	if g != nil && g.Now != nil {
+		return g.Now()
+	}
+
+	return time.Now()
+}
+
+// MemorySeenStore is a SeenStore keeping the most recently seen message IDs in memory.
+// It is safe for concurrent use.
+type MemorySeenStore struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type seenEntry struct {
+	messageID string
+	expiresAt time.Time
+}
+
+// NewMemorySeenStore returns a store holding up to capacity message IDs (10000 when not positive); the least
+// recently seen ones are forgotten first.
+func NewMemorySeenStore(capacity int) *MemorySeenStore {
+	if capacity <= 0 {
+		capacity = defaultSeenStoreCapacity
+	}
+
+	return &MemorySeenStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (s *MemorySeenStore) MarkSeen(_ context.Context, messageID string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.removeExpiredLocked(now)
+
+	if element, ok := s.entries[messageID]; ok {
+		s.order.MoveToFront(element)
+
+		entry, _ := element.Value.(*seenEntry)
+		if now.Before(entry.expiresAt) {
+			return true, nil
+		}
+
+		entry.expiresAt = now.Add(ttl)
+
+		return false, nil
+	}
+
+	s.entries[messageID] = s.order.PushFront(&seenEntry{messageID: messageID, expiresAt: now.Add(ttl)})
+
+	for s.order.Len() > s.capacity {
+		s.removeLocked(s.order.Back())
+	}
+
+	return false, nil
+}
+
+func (s *MemorySeenStore) Forget(_ context.Context, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[messageID]; ok {
+		s.removeLocked(element)
+	}
+
+	return nil
+}
+
+// removeExpiredLocked forgets the expired message IDs among the least recently seen ones.
+func (s *MemorySeenStore) removeExpiredLocked(now time.Time) {
+	for element := s.order.Back(); element != nil; element = s.order.Back() {
+		entry, _ := element.Value.(*seenEntry)
+		if now.Before(entry.expiresAt) {
+			return
+		}
+
+		s.removeLocked(element)
+	}
+}
+
+func (s *MemorySeenStore) removeLocked(element *list.Element) {
+	entry, _ := element.Value.(*seenEntry)
+	delete(s.entries, entry.messageID)
+	s.order.Remove(element)
+}
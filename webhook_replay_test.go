@@ -0,0 +1,237 @@
+package gokick_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var webhookTime = time.Date(2025, time.February, 21, 23, 23, 36, 0, time.UTC)
+
+type failingSeenStore struct{}
+
+func (failingSeenStore) MarkSeen(context.Context, string, time.Duration) (bool, error) {
+	return false, errors.New("store down")
+}
+
+func (failingSeenStore) Forget(context.Context, string) error {
+	return errors.New("store down")
+}
+
+func newReplayGuard() *gokick.ReplayGuard {
+	guard := gokick.NewReplayGuard()
+	guard.Now = func() time.Time { return webhookTime.Add(time.Minute) }
+
+	return guard
+}
+
+func TestReplayGuardError(t *testing.T) {
+	testCases := map[string]struct {
+		guard     *gokick.ReplayGuard
+		timestamp string
+		expected  string
+	}{
+		"invalid timestamp": {
+			guard:     newReplayGuard(),
+			timestamp: "yesterday",
+			expected: `failed to parse event timestamp: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": ` +
+				`cannot parse "yesterday" as "2006"`,
+		},
+		"too old": {
+			guard:     newReplayGuard(),
+			timestamp: webhookTime.Add(-10 * time.Minute).Format(time.RFC3339),
+			expected:  "event timestamp outside the tolerance window",
+		},
+		"in the future": {
+			guard:     newReplayGuard(),
+			timestamp: webhookTime.Add(10 * time.Minute).Format(time.RFC3339),
+			expected:  "event timestamp outside the tolerance window",
+		},
+		"store failure": {
+			guard: &gokick.ReplayGuard{
+				Tolerance: time.Hour,
+				Store:     failingSeenStore{},
+				Now:       func() time.Time { return webhookTime },
+			},
+			timestamp: webhookTime.Format(time.RFC3339),
+			expected:  "failed to record event message ID: store down",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.guard.Check(context.Background(), "message-id", tc.timestamp)
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+
+	t.Run("replayed", func(t *testing.T) {
+		guard := newReplayGuard()
+
+		require.NoError(t, guard.Check(context.Background(), "message-id", webhookTime.Format(time.RFC3339)))
+
+		err := guard.Check(context.Background(), "message-id", webhookTime.Format(time.RFC3339))
+		require.ErrorIs(t, err, gokick.ErrEventReplayed)
+	})
+}
+
+func TestReplayGuardSuccess(t *testing.T) {
+	guard := newReplayGuard()
+
+	for _, messageID := range []string{"first", "second"} {
+		require.NoError(t, guard.Check(context.Background(), messageID, webhookTime.Format(time.RFC3339)))
+	}
+
+	require.NoError(t, guard.Forget(context.Background(), "first"))
+	require.NoError(t, guard.Check(context.Background(), "first", webhookTime.Format(time.RFC3339)))
+
+	guard.Store = nil
+	require.NoError(t, guard.Check(context.Background(), "first", webhookTime.Format(time.RFC3339)))
+
+	t.Run("zero value", func(t *testing.T) {
+		guard := &gokick.ReplayGuard{Now: func() time.Time { return webhookTime.Add(time.Minute) }}
+		require.NoError(t, guard.Check(context.Background(), "message-id", webhookTime.Format(time.RFC3339)))
+
+		err := guard.Check(context.Background(), "message-id", webhookTime.Add(-10*time.Minute).Format(time.RFC3339))
+		require.ErrorIs(t, err, gokick.ErrEventTooOld)
+	})
+}
+
+func TestMemorySeenStore(t *testing.T) {
+	t.Run("least recently seen forgotten first", func(t *testing.T) {
+		store := gokick.NewMemorySeenStore(2)
+
+		for _, messageID := range []string{"a", "b", "a", "c"} {
+			_, err := store.MarkSeen(context.Background(), messageID, time.Hour)
+			require.NoError(t, err)
+		}
+
+		seen, err := store.MarkSeen(context.Background(), "a", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, seen)
+
+		seen, err = store.MarkSeen(context.Background(), "b", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, seen)
+	})
+
+	t.Run("default capacity", func(t *testing.T) {
+		store := gokick.NewMemorySeenStore(0)
+
+		_, err := store.MarkSeen(context.Background(), "a", time.Hour)
+		require.NoError(t, err)
+
+		seen, err := store.MarkSeen(context.Background(), "a", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, seen)
+	})
+
+	t.Run("forgotten", func(t *testing.T) {
+		store := gokick.NewMemorySeenStore(10)
+
+		_, err := store.MarkSeen(context.Background(), "a", time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, store.Forget(context.Background(), "a"))
+		require.NoError(t, store.Forget(context.Background(), "unknown"))
+
+		seen, err := store.MarkSeen(context.Background(), "a", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, seen)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		store := gokick.NewMemorySeenStore(10)
+
+		seen, err := store.MarkSeen(context.Background(), "a", time.Millisecond)
+		require.NoError(t, err)
+		assert.False(t, seen)
+
+		time.Sleep(5 * time.Millisecond)
+
+		seen, err = store.MarkSeen(context.Background(), "a", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, seen)
+	})
+}
+
+func TestWebhookHandlerReplayGuard(t *testing.T) {
+	skipSignatureValidation(t)
+
+	var received int
+	var errs []error
+
+	handler := gokick.NewWebhookHandler()
+	handler.SetReplayGuard(newReplayGuard())
+	handler.OnChannelFollow(func(context.Context, *gokick.ChannelFollowEvent) { received++ })
+	handler.OnError(func(_ *http.Request, err error) { errs = append(errs, err) })
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.followed", "{}"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.followed", "{}"))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	req := newWebhookRequest(t, "channel.followed", "{}")
+	req.Header.Set("Kick-Event-Message-Id", "other message ID")
+	req.Header.Set("Kick-Event-Message-Timestamp", webhookTime.Add(-time.Hour).Format(time.RFC3339))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	assert.Equal(t, 1, received)
+	require.Len(t, errs, 2)
+	require.ErrorIs(t, errs[0], gokick.ErrEventReplayed)
+	require.ErrorIs(t, errs[1], gokick.ErrEventTooOld)
+}
+
+func TestWebhookHandlerReplayGuardRetry(t *testing.T) {
+	skipSignatureValidation(t)
+
+	t.Run("invalid payload", func(t *testing.T) {
+		var received int
+
+		handler := gokick.NewWebhookHandler()
+		handler.SetReplayGuard(newReplayGuard())
+		handler.OnChannelFollow(func(context.Context, *gokick.ChannelFollowEvent) { received++ })
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.followed", "invalid"))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.followed", "{}"))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, 1, received)
+	})
+
+	t.Run("panicking callback", func(t *testing.T) {
+		var received int
+
+		handler := gokick.NewWebhookHandler()
+		handler.SetReplayGuard(newReplayGuard())
+		handler.OnChannelFollow(func(context.Context, *gokick.ChannelFollowEvent) {
+			received++
+			if received == 1 {
+				panic("database down")
+			}
+		})
+
+		assert.PanicsWithValue(t, "database down", func() {
+			handler.ServeHTTP(httptest.NewRecorder(), newWebhookRequest(t, "channel.followed", "{}"))
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newWebhookRequest(t, "channel.followed", "{}"))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, 2, received)
+	})
+}
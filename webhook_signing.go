@@ -0,0 +1,98 @@
+package gokick
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SignEvent signs a webhook as KICK does, and returns the value of its Kick-Event-Signature header.
+// timestamp is the value of the Kick-Event-Message-Timestamp header, e.g. "2025-02-21T23:23:36Z".
+func SignEvent(privateKey *rsa.PrivateKey, messageID string, timestamp string, body []byte) (string, error) {
+	hashed := sha256.Sum256(eventSignaturePayload(messageID, timestamp, body))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign event: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// NewSignedWebhookRequest builds a webhook POST request to url, with the Kick-Event-* headers, signed with
+// privateKey. The message ID is generated when empty, the timestamp is the current time when both Timestamp and
+// RawTimestamp are empty, and the version is "1" when empty. headers.Signature is ignored.
+func NewSignedWebhookRequest(
+	ctx context.Context,
+	privateKey *rsa.PrivateKey,
+	url string,
+	headers WebhookHeaders,
+	body []byte,
+) (*http.Request, error) {
+	if headers.Name == "" {
+		headers.Name = headers.Subscription.String()
+	}
+
+	if headers.Version == "" {
+		headers.Version = "1"
+	}
+
+	if headers.MessageID == "" {
+		messageID, err := randomMessageID()
+		if err != nil {
+			return nil, err
+		}
+		headers.MessageID = messageID
+	}
+
+	if headers.RawTimestamp == "" {
+		if headers.Timestamp.IsZero() {
+			headers.Timestamp = time.Now()
+		}
+		headers.RawTimestamp = headers.Timestamp.UTC().Format(time.RFC3339)
+	}
+
+	signature, err := SignEvent(privateKey, headers.MessageID, headers.RawTimestamp, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(subscriptionHeader.name, headers.Name)
+	request.Header.Set(versionHeader.name, headers.Version)
+	request.Header.Set(subscriptionIDHeader.name, headers.SubscriptionID)
+	request.Header.Set(messageIDHeader.name, headers.MessageID)
+	request.Header.Set(timestampHeader.name, headers.RawTimestamp)
+	request.Header.Set(signatureHeader.name, signature)
+
+	return request, nil
+}
+
+// eventSignaturePayload is the data signed by KICK.
+func eventSignaturePayload(messageID string, timestamp string, body []byte) []byte {
+	return []byte(fmt.Sprintf("%s.%s.%s", messageID, timestamp, body))
+}
+
+func randomMessageID() (string, error) {
+	id := make([]byte, 16)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate message ID: %w", err)
+	}
+
+	return hex.EncodeToString(id), nil
+}
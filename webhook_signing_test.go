@@ -0,0 +1,141 @@
+package gokick_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scorfly/gokick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSigningKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return privateKey
+}
+
+func TestSignEventError(t *testing.T) {
+	privateKey := newSigningKey(t)
+	verifier := &gokick.EventVerifier{PublicKey: gokick.NewStaticPublicKeyProviderFromKey(&privateKey.PublicKey)}
+
+	signature, err := gokick.SignEvent(privateKey, "message ID", "2025-02-21T23:23:36Z", []byte(`{"content":"hello"}`))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		messageID string
+		timestamp string
+		body      string
+	}{
+		"tampered body":      {messageID: "message ID", timestamp: "2025-02-21T23:23:36Z", body: `{"content":"bye"}`},
+		"tampered ID":        {messageID: "other ID", timestamp: "2025-02-21T23:23:36Z", body: `{"content":"hello"}`},
+		"tampered timestamp": {messageID: "message ID", timestamp: "2025-02-21T23:23:37Z", body: `{"content":"hello"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.ValidateAndParse(
+				context.Background(),
+				gokick.SubscriptionNameChatMessage,
+				"1",
+				signature,
+				tc.messageID,
+				tc.timestamp,
+				[]byte(tc.body),
+			)
+			require.EqualError(t, err, "failed to verify event validity: failed to verify signature: crypto/rsa: verification error")
+		})
+	}
+
+	t.Run("signed with another key", func(t *testing.T) {
+		request, err := gokick.NewSignedWebhookRequest(
+			context.Background(),
+			newSigningKey(t),
+			"/webhook",
+			gokick.WebhookHeaders{Subscription: gokick.SubscriptionNameChatMessage},
+			[]byte("{}"),
+		)
+		require.NoError(t, err)
+
+		_, err = verifier.ParseRequest(request)
+		require.EqualError(t, err, "failed to verify event validity: failed to verify signature: crypto/rsa: verification error")
+	})
+}
+
+func TestSignEventSuccess(t *testing.T) {
+	privateKey := newSigningKey(t)
+	verifier := &gokick.EventVerifier{PublicKey: gokick.NewStaticPublicKeyProviderFromKey(&privateKey.PublicKey)}
+
+	t.Run("sign event", func(t *testing.T) {
+		signature, err := gokick.SignEvent(privateKey, "message ID", "2025-02-21T23:23:36Z", []byte(`{"content":"hello"}`))
+		require.NoError(t, err)
+
+		event, err := verifier.ValidateAndParse(
+			context.Background(),
+			gokick.SubscriptionNameChatMessage,
+			"1",
+			signature,
+			"message ID",
+			"2025-02-21T23:23:36Z",
+			[]byte(`{"content":"hello"}`),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", event.(*gokick.ChatMessageEvent).Content)
+	})
+
+	t.Run("signed request", func(t *testing.T) {
+		request, err := gokick.NewSignedWebhookRequest(
+			context.Background(),
+			privateKey,
+			"/webhook",
+			gokick.WebhookHeaders{
+				Subscription:   gokick.SubscriptionNameKicksGifted,
+				SubscriptionID: "01JMN13xxxxxx",
+				Timestamp:      webhookTime,
+			},
+			[]byte(`{"gift":{"amount":100}}`),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "kicks.gifted", request.Header.Get("Kick-Event-Type"))
+		assert.Equal(t, "1", request.Header.Get("Kick-Event-Version"))
+		assert.Equal(t, "01JMN13xxxxxx", request.Header.Get("Kick-Event-Subscription-Id"))
+		assert.Equal(t, "2025-02-21T23:23:36Z", request.Header.Get("Kick-Event-Message-Timestamp"))
+		assert.NotEmpty(t, request.Header.Get("Kick-Event-Message-Id"))
+
+		event, err := verifier.ParseRequestEvent(request)
+		require.NoError(t, err)
+		assert.Equal(t, request.Header.Get("Kick-Event-Message-Id"), event.ID)
+		assert.Equal(t, 100, event.Payload.(*gokick.KicksGiftedEvent).Gift.Amount)
+	})
+
+	t.Run("webhook handler", func(t *testing.T) {
+		var event *gokick.ChannelFollowEvent
+
+		handler := gokick.NewWebhookHandler()
+		handler.SetVerifier(verifier)
+		handler.OnChannelFollow(func(_ context.Context, e *gokick.ChannelFollowEvent) { event = e })
+
+		request, err := gokick.NewSignedWebhookRequest(
+			context.Background(),
+			privateKey,
+			"/webhook",
+			gokick.WebhookHeaders{Name: "channel.followed", Version: "1", MessageID: "message ID"},
+			[]byte(`{"follower":{"username":"alice"}}`),
+		)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		require.NotNil(t, event)
+		assert.Equal(t, "alice", event.Follower.Username)
+	})
+}